@@ -247,10 +247,24 @@ func (ca *CharacterArray) Get() []interface{} {
 // nolint
 func (ca *CharacterArray) Set(vs []interface{}) {
 	for _, v := range vs {
+		if c, ok := v.(JavaChar); ok {
+			ca.Values = ca.Values + c.String()
+			continue
+		}
 		ca.Values = ca.Values + v.(string)
 	}
 }
 
+// NewCharacterArray creates the CharacterArray of the chars.
+func NewCharacterArray(chars []JavaChar) *CharacterArray {
+	return &CharacterArray{Values: JavaCharsString(chars)}
+}
+
+// JavaChars returns the UTF-16 code units of the values.
+func (ca *CharacterArray) JavaChars() []JavaChar {
+	return JavaCharsOf(ca.Values)
+}
+
 // nolint
 func (*CharacterArray) JavaClassName() string {
 	return "[java.lang.Character"
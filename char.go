@@ -0,0 +1,160 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"reflect"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+// JavaChar is the char of java, a UTF-16 code unit. It's encoded as a string of one character like java hessian,
+// and []JavaChar is encoded as a string like the char[] of java.
+type JavaChar uint16
+
+var (
+	javaCharType      = reflect.TypeOf(JavaChar(0))
+	javaCharSliceType = reflect.TypeOf([]JavaChar{})
+)
+
+// String returns the character, the surrogate is replaced by utf8.RuneError.
+func (c JavaChar) String() string {
+	return string(rune(c))
+}
+
+// JavaCharsOf converts the string to the UTF-16 code units, like String.toCharArray() of java.
+func JavaCharsOf(s string) []JavaChar {
+	chars := make([]JavaChar, 0, len(s))
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			// a single surrogate written by java, which is not valid utf-8
+			if c, ok := decodeSurrogate(s[i:]); ok {
+				chars = append(chars, c)
+				i += 3
+				continue
+			}
+		}
+		for _, u := range utf16.Encode([]rune{r}) {
+			chars = append(chars, JavaChar(u))
+		}
+		i += size
+	}
+	return chars
+}
+
+// JavaCharsString converts the UTF-16 code units to string, the single surrogates are replaced by utf8.RuneError.
+func JavaCharsString(chars []JavaChar) string {
+	units := make([]uint16, len(chars))
+	for i, c := range chars {
+		units[i] = uint16(c)
+	}
+	return string(utf16.Decode(units))
+}
+
+func decodeSurrogate(s string) (JavaChar, bool) {
+	if len(s) < 3 || s[0] != 0xed || s[1]&0xe0 != 0xa0 || s[2]&0xc0 != 0x80 {
+		return 0, false
+	}
+	return JavaChar(uint32(s[0]&0x0f)<<12 | uint32(s[1]&0x3f)<<6 | uint32(s[2]&0x3f)), true
+}
+
+// encJavaChars encodes the chars as a string like java hessian, each char is written in utf-8 separately
+// so that the single surrogates are kept, and the chunks don't split the surrogate pairs.
+func encJavaChars(b []byte, chars []JavaChar) []byte {
+	if len(chars) == 0 {
+		return encByte(b, BC_STRING_DIRECT)
+	}
+
+	buf := make([]byte, 3)
+	for len(chars) > 0 {
+		n := len(chars)
+		if n > CHUNK_SIZE {
+			n = CHUNK_SIZE
+			if tail := chars[n-1]; tail >= 0xd800 && tail <= 0xdbff {
+				n--
+			}
+		}
+
+		switch {
+		case n < len(chars):
+			b = encByte(b, BC_STRING_CHUNK)
+			b = encByte(b, PackUint16(uint16(n))...)
+		case n <= int(STRING_DIRECT_MAX):
+			b = encByte(b, byte(n+int(BC_STRING_DIRECT)))
+		case n <= STRING_SHORT_MAX:
+			b = encByte(b, byte((n>>8)+int(BC_STRING_SHORT)), byte(n))
+		default:
+			b = encByte(b, BC_STRING)
+			b = encByte(b, PackUint16(uint16(n))...)
+		}
+
+		for _, c := range chars[:n] {
+			size := encodeUcs2Rune(buf, uint32(c))
+			b = append(b, buf[:size]...)
+		}
+		chars = chars[n:]
+	}
+	return b
+}
+
+// toJavaChar converts the decoded string of one character, or the int written by the old versions, to JavaChar.
+func toJavaChar(v interface{}) (JavaChar, error) {
+	switch c := v.(type) {
+	case nil:
+		return 0, nil
+	case string:
+		chars := JavaCharsOf(c)
+		if len(chars) != 1 {
+			return 0, perrors.Errorf("expect one char, but get %q", c)
+		}
+		return chars[0], nil
+	case int32:
+		return JavaChar(c), nil
+	case int64:
+		return JavaChar(c), nil
+	}
+	return 0, perrors.Errorf("can not convert %T to char", v)
+}
+
+// toJavaChars converts the decoded string, or the list of chars, to []JavaChar.
+func toJavaChars(v interface{}) ([]JavaChar, error) {
+	switch c := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return JavaCharsOf(c), nil
+	case []interface{}:
+		chars := make([]JavaChar, len(c))
+		for i := range c {
+			char, err := toJavaChar(c[i])
+			if err != nil {
+				return nil, err
+			}
+			chars[i] = char
+		}
+		return chars, nil
+	case *CharacterArray:
+		return c.JavaChars(), nil
+	}
+	return nil, perrors.Errorf("can not convert %T to char array", v)
+}
@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"strings"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+type charHolder struct {
+	Grade   JavaChar   `hessian:"grade"`
+	Initial *JavaChar  `hessian:"initial"`
+	Code    []JavaChar `hessian:"code"`
+	Empty   []JavaChar `hessian:"empty"`
+}
+
+func (charHolder) JavaClassName() string {
+	return "test.model.CharHolder"
+}
+
+func TestJavaChar(t *testing.T) {
+	RegisterPOJO(&charHolder{})
+
+	initial := JavaChar('中')
+	holder := &charHolder{
+		Grade:   'A',
+		Initial: &initial,
+		Code:    JavaCharsOf("go😀"),
+	}
+	assert.Equal(t, 4, len(holder.Code))
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(holder))
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, holder, res)
+
+	// the same bytes as the strings
+	e = NewEncoder()
+	assert.Nil(t, e.Encode(JavaChar('A')))
+	assert.Equal(t, []byte{0x01, 'A'}, e.Buffer())
+
+	long := strings.Repeat("中a", CHUNK_SIZE)
+	e = NewEncoder()
+	assert.Nil(t, e.Encode(JavaCharsOf(long)))
+	expected := NewEncoder()
+	assert.Nil(t, expected.Encode(long))
+	assert.Equal(t, expected.Buffer(), e.Buffer())
+
+	// the surrogate pair is not split by the chunks
+	chars := JavaCharsOf(strings.Repeat("a", CHUNK_SIZE-1) + "😀")
+	e = NewEncoder()
+	assert.Nil(t, e.Encode(chars))
+	res, err = NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, chars, JavaCharsOf(res.(string)))
+
+	// the single low surrogate is kept
+	chars = []JavaChar{'a', 0xdc00}
+	e = NewEncoder()
+	assert.Nil(t, e.Encode(chars))
+	res, err = NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, chars, JavaCharsOf(res.(string)))
+
+	assert.Equal(t, "C", getArgType(JavaChar('a')))
+	assert.Equal(t, "[C", getArgType(chars))
+
+	ca := NewCharacterArray(JavaCharsOf("hi😀"))
+	assert.Equal(t, "hi😀", ca.Values)
+	assert.Equal(t, JavaCharsOf("hi😀"), ca.JavaChars())
+}
@@ -327,6 +327,12 @@ func SetValue(dest, v reflect.Value) {
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		dest.SetUint(v.Uint())
 		return
+	case reflect.String:
+		// the java enum of go string type
+		if enumValue, ok := convertStringEnum(v.Interface(), dest.Type()); ok {
+			dest.Set(enumValue)
+			return
+		}
 	}
 
 	dest.Set(v)
@@ -369,6 +375,11 @@ func SetSlice(dest reflect.Value, objects interface{}) error {
 		return unpackRefHolder(dest, destTyp, ref)
 	}
 
+	// the registered java collection like java.util.HashSet
+	if c, ok := objects.(JavaCollectionObject); ok {
+		objects = c.Get()
+	}
+
 	v := EnsurePackValue(objects)
 	if h, ok := v.Interface().(*_refHolder); ok {
 		// if the object is a ref one, just add the destination list to wait delay initialization
@@ -41,6 +41,19 @@ type Decoder struct {
 	// In non-strict mode, a class data will be decoded to a map when the class is not registered.
 	// The default is non-strict mode, user can change it as required.
 	Strict bool
+
+	// If UseOrderedMap is true, the untyped maps and the typed maps of unregistered classes are decoded
+	// to *OrderedMap which keeps the order of entries, otherwise to map[interface{}]interface{}.
+	UseOrderedMap bool
+
+	// If DisallowUnknownEnum is true, decoding an unknown name of a registered enum returns an error,
+	// otherwise InvalidJavaEnum is returned.
+	DisallowUnknownEnum bool
+
+	// If DetectJavaEnumValue is true, the objects of the unregistered classes with the only field "name",
+	// which is how java writes enums, are decoded to JavaEnumValue, otherwise to maps.
+	// The classes registered by RegisterJavaEnumValues are always decoded to JavaEnumValue.
+	DetectJavaEnumValue bool
 }
 
 // FindClassInfo find ClassInfo for the given name in decoder class info list.
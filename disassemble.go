@@ -0,0 +1,892 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+// max bytes shown in one line of the listing
+const instructionHexMax = 12
+
+// max chars of a string shown in a comment
+const instructionTextMax = 48
+
+// Instruction is one annotated token of a hessian payload, eg: a tag with its length, or a string chunk.
+type Instruction struct {
+	// Offset is the position of the first byte in the payload
+	Offset int
+	// Bytes is the raw bytes of the token
+	Bytes []byte
+	// Depth is the nesting level of the token, the items of a list/map/object are one level deeper
+	Depth int
+	// Comment describes the token, eg: `object #1 (class def #0 example.Car)`
+	Comment string
+}
+
+// String formats the instruction as a listing line.
+func (inst Instruction) String() string {
+	var hex strings.Builder
+	for i, b := range inst.Bytes {
+		if i == instructionHexMax {
+			hex.WriteString("..")
+			break
+		}
+		if i > 0 {
+			hex.WriteByte(' ')
+		}
+		fmt.Fprintf(&hex, "%02x", b)
+	}
+
+	indent := strings.Repeat("  ", inst.Depth)
+	return fmt.Sprintf("%06x  %-40s # %s%s", inst.Offset, hex.String(), indent, inst.Comment)
+}
+
+// ValueKind is the kind of a decoded hessian value.
+type ValueKind string
+
+// the kinds of ValueNode
+const (
+	KindNull   ValueKind = "null"
+	KindBool   ValueKind = "bool"
+	KindInt    ValueKind = "int"
+	KindLong   ValueKind = "long"
+	KindDouble ValueKind = "double"
+	KindDate   ValueKind = "date"
+	KindString ValueKind = "string"
+	KindBinary ValueKind = "binary"
+	KindList   ValueKind = "list"
+	KindMap    ValueKind = "map"
+	KindObject ValueKind = "object"
+	KindRef    ValueKind = "ref"
+)
+
+// ValueNode is a decoded hessian value which keeps the wire information,
+// it can be built without any POJO registration.
+type ValueNode struct {
+	// Offset is the position of the value in the payload
+	Offset int
+	Kind   ValueKind
+	// Type is the type of a list or map, or the class name of an object
+	Type string
+	// Value is the value of a scalar: bool, int32, int64, float64, time.Time, string or []byte
+	Value interface{}
+	// Ref is the ref index of a list, map or object, or the referred index of a ref
+	Ref int
+	// Fields is the field names of an object, the field values are in Children
+	Fields []string
+	// Keys is the keys of a map, the values are in Children
+	Keys []*ValueNode
+	// Children is the items of a list, values of a map, or field values of an object
+	Children []*ValueNode
+}
+
+type disassembleClassDef struct {
+	name   string
+	fields []string
+}
+
+type disassembler struct {
+	buf       []byte
+	pos       int
+	depth     int
+	insts     []Instruction
+	label     string
+	types     []string
+	classDefs []disassembleClassDef
+	refs      int
+}
+
+// Disassemble decodes all values in a hessian payload into an annotated listing of every tag,
+// class definition, ref index and chunk. On error, the instructions decoded before it are returned too.
+func Disassemble(b []byte) ([]Instruction, error) {
+	d := &disassembler{buf: b}
+	_, err := d.parseAll()
+	return d.insts, err
+}
+
+// ParseValueTree decodes all values in a hessian payload into value trees without any POJO registration.
+// On error, the values decoded before it are returned too.
+func ParseValueTree(b []byte) ([]*ValueNode, error) {
+	d := &disassembler{buf: b}
+	return d.parseAll()
+}
+
+func (d *disassembler) parseAll() ([]*ValueNode, error) {
+	var nodes []*ValueNode
+	for d.pos < len(d.buf) {
+		node, err := d.parseValue()
+		if err != nil {
+			return nodes, err
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// emit adds an instruction for the bytes from start to the current position.
+func (d *disassembler) emit(start int, format string, args ...interface{}) {
+	comment := fmt.Sprintf(format, args...)
+	if d.label != "" {
+		comment = d.label + ": " + comment
+		d.label = ""
+	}
+	d.insts = append(d.insts, Instruction{
+		Offset:  start,
+		Bytes:   d.buf[start:d.pos],
+		Depth:   d.depth,
+		Comment: comment,
+	})
+}
+
+func (d *disassembler) read(n int) ([]byte, error) {
+	if n < 0 || d.pos+n > len(d.buf) {
+		return nil, perrors.Errorf("unexpected end of data at offset %#x, need %d bytes", d.pos, n)
+	}
+	b := d.buf[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *disassembler) readByte() (byte, error) {
+	b, err := d.read(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (d *disassembler) peekByte() (byte, error) {
+	if d.pos >= len(d.buf) {
+		return 0, perrors.Errorf("unexpected end of data at offset %#x", d.pos)
+	}
+	return d.buf[d.pos], nil
+}
+
+func (d *disassembler) readUint16() (int, error) {
+	b, err := d.read(2)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint16(b)), nil
+}
+
+func (d *disassembler) parseValue() (*ValueNode, error) {
+	start := d.pos
+	tag, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	node := &ValueNode{Offset: start, Ref: -1}
+
+	switch {
+	case tag == BC_NULL:
+		node.Kind = KindNull
+		d.emit(start, "null")
+
+	case tag == BC_TRUE || tag == BC_FALSE:
+		node.Kind = KindBool
+		node.Value = tag == BC_TRUE
+		d.emit(start, "%v", node.Value)
+
+	case tag >= 0x80 && tag <= 0xd7, tag == BC_INT:
+		node.Kind = KindInt
+		v, err := d.readInt(tag)
+		if err != nil {
+			return nil, err
+		}
+		node.Value = v
+		d.emit(start, "int %d", v)
+
+	case tag >= 0xd8, tag >= 0x38 && tag <= 0x3f, tag == BC_LONG_INT, tag == BC_LONG:
+		node.Kind = KindLong
+		v, err := d.readLong(tag)
+		if err != nil {
+			return nil, err
+		}
+		node.Value = v
+		d.emit(start, "long %d", v)
+
+	case tag >= BC_DOUBLE_ZERO && tag <= BC_DOUBLE_MILL, tag == BC_DOUBLE:
+		node.Kind = KindDouble
+		v, err := d.readDouble(tag)
+		if err != nil {
+			return nil, err
+		}
+		node.Value = v
+		d.emit(start, "double %s", strconv.FormatFloat(v, 'g', -1, 64))
+
+	case tag == BC_DATE || tag == BC_DATE_MINUTE:
+		node.Kind = KindDate
+		v, err := d.readDate(tag)
+		if err != nil {
+			return nil, err
+		}
+		node.Value = v
+		d.emit(start, "date %s", v.Format(time.RFC3339Nano))
+
+	case tag <= STRING_DIRECT_MAX, tag >= 0x30 && tag <= 0x33, tag == BC_STRING, tag == BC_STRING_CHUNK:
+		d.pos = start
+		s, err := d.parseString("string")
+		if err != nil {
+			return nil, err
+		}
+		node.Kind = KindString
+		node.Value = s
+
+	case tag >= 0x20 && tag <= 0x2f, tag >= 0x34 && tag <= 0x37, tag == BC_BINARY, tag == BC_BINARY_CHUNK:
+		d.pos = start
+		v, err := d.parseBinary()
+		if err != nil {
+			return nil, err
+		}
+		node.Kind = KindBinary
+		node.Value = v
+
+	case tag == BC_LIST_VARIABLE, tag == BC_LIST_FIXED, tag == BC_LIST_VARIABLE_UNTYPED,
+		tag == BC_LIST_FIXED_UNTYPED, tag >= BC_LIST_DIRECT && tag <= 0x7f:
+		if err = d.parseList(tag, node); err != nil {
+			return nil, err
+		}
+
+	case tag == BC_MAP || tag == BC_MAP_UNTYPED:
+		if err = d.parseMap(tag, node); err != nil {
+			return nil, err
+		}
+
+	case tag == BC_OBJECT_DEF:
+		// the label belongs to the object after the class definition
+		label := d.label
+		d.label = ""
+		d.pos = start
+		if err = d.parseClassDef(); err != nil {
+			return nil, err
+		}
+		d.label = label
+		// a class definition is not a value, the value follows it
+		return d.parseValue()
+
+	case tag == BC_OBJECT, tag >= BC_OBJECT_DIRECT && tag <= 0x6f:
+		if err = d.parseObject(tag, node); err != nil {
+			return nil, err
+		}
+
+	case tag == BC_REF:
+		v, err := d.readIntValue()
+		if err != nil {
+			return nil, err
+		}
+		if v < 0 || int(v) >= d.refs {
+			return nil, perrors.Errorf("illegal ref index %d at offset %#x", v, start)
+		}
+		node.Kind = KindRef
+		node.Ref = int(v)
+		d.emit(start, "ref to #%d", v)
+
+	default:
+		return nil, perrors.Errorf("unknown tag %#x at offset %#x", tag, start)
+	}
+
+	return node, nil
+}
+
+// readIntValue reads an int value, which is used as a length or an index.
+func (d *disassembler) readIntValue() (int32, error) {
+	start := d.pos
+	tag, err := d.readByte()
+	if err != nil {
+		return 0, err
+	}
+	if !(tag >= 0x80 && tag <= 0xd7) && tag != BC_INT {
+		return 0, perrors.Errorf("expect int at offset %#x, but got tag %#x", start, tag)
+	}
+	return d.readInt(tag)
+}
+
+// # 32-bit signed integer
+// ::= 'I' b3 b2 b1 b0
+// ::= [x80-xbf]             # -x10 to x3f
+// ::= [xc0-xcf] b0          # -x800 to x7ff
+// ::= [xd0-xd7] b1 b0       # -x40000 to x3ffff
+func (d *disassembler) readInt(tag byte) (int32, error) {
+	switch {
+	case tag >= 0x80 && tag <= 0xbf:
+		return int32(tag) - int32(BC_INT_ZERO), nil
+
+	case tag >= 0xc0 && tag <= 0xcf:
+		b, err := d.read(1)
+		if err != nil {
+			return 0, err
+		}
+		return (int32(tag)-int32(BC_INT_BYTE_ZERO))<<8 + int32(b[0]), nil
+
+	case tag >= 0xd0 && tag <= 0xd7:
+		b, err := d.read(2)
+		if err != nil {
+			return 0, err
+		}
+		return (int32(tag)-int32(BC_INT_SHORT_ZERO))<<16 + int32(b[0])<<8 + int32(b[1]), nil
+
+	default:
+		b, err := d.read(4)
+		if err != nil {
+			return 0, err
+		}
+		return int32(binary.BigEndian.Uint32(b)), nil
+	}
+}
+
+// # 64-bit signed long integer
+// ::= 'L' b7 b6 b5 b4 b3 b2 b1 b0
+// ::= [xd8-xef]             # -x08 to x0f
+// ::= [xf0-xff] b0          # -x800 to x7ff
+// ::= [x38-x3f] b1 b0       # -x40000 to x3ffff
+// ::= x59 b3 b2 b1 b0       # 32-bit integer cast to long
+func (d *disassembler) readLong(tag byte) (int64, error) {
+	switch {
+	case tag >= 0xd8 && tag <= 0xef:
+		return int64(tag) - int64(BC_LONG_ZERO), nil
+
+	case tag >= 0xf0:
+		b, err := d.read(1)
+		if err != nil {
+			return 0, err
+		}
+		return (int64(tag)-int64(BC_LONG_BYTE_ZERO))<<8 + int64(b[0]), nil
+
+	case tag >= 0x38 && tag <= 0x3f:
+		b, err := d.read(2)
+		if err != nil {
+			return 0, err
+		}
+		return (int64(tag)-int64(BC_LONG_SHORT_ZERO))<<16 + int64(b[0])<<8 + int64(b[1]), nil
+
+	case tag == BC_LONG_INT:
+		b, err := d.read(4)
+		if err != nil {
+			return 0, err
+		}
+		return int64(int32(binary.BigEndian.Uint32(b))), nil
+
+	default:
+		b, err := d.read(8)
+		if err != nil {
+			return 0, err
+		}
+		return int64(binary.BigEndian.Uint64(b)), nil
+	}
+}
+
+// # 64-bit IEEE double
+// ::= 'D' b7 b6 b5 b4 b3 b2 b1 b0
+// ::= x5b                   # 0.0
+// ::= x5c                   # 1.0
+// ::= x5d b0                # byte cast to double (-128.0 to 127.0)
+// ::= x5e b1 b0             # short cast to double
+// ::= x5f b3 b2 b1 b0       # 32-bit float cast to double
+func (d *disassembler) readDouble(tag byte) (float64, error) {
+	switch tag {
+	case BC_DOUBLE_ZERO:
+		return 0, nil
+
+	case BC_DOUBLE_ONE:
+		return 1, nil
+
+	case BC_DOUBLE_BYTE:
+		b, err := d.read(1)
+		if err != nil {
+			return 0, err
+		}
+		return float64(int8(b[0])), nil
+
+	case BC_DOUBLE_SHORT:
+		b, err := d.read(2)
+		if err != nil {
+			return 0, err
+		}
+		return float64(int16(binary.BigEndian.Uint16(b))), nil
+
+	case BC_DOUBLE_MILL:
+		b, err := d.read(4)
+		if err != nil {
+			return 0, err
+		}
+		return float64(int32(binary.BigEndian.Uint32(b))) / 1000, nil
+
+	default:
+		b, err := d.read(8)
+		if err != nil {
+			return 0, err
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+	}
+}
+
+// # time in UTC encoded as 64-bit long milliseconds since epoch
+// ::= x4a b7 b6 b5 b4 b3 b2 b1 b0
+// ::= x4b b3 b2 b1 b0       # minutes since epoch
+func (d *disassembler) readDate(tag byte) (time.Time, error) {
+	if tag == BC_DATE_MINUTE {
+		b, err := d.read(4)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(int64(int32(binary.BigEndian.Uint32(b)))*60, 0).UTC(), nil
+	}
+
+	b, err := d.read(8)
+	if err != nil {
+		return time.Time{}, err
+	}
+	ms := int64(binary.BigEndian.Uint64(b))
+	return time.Unix(ms/1000, ms%1000*int64(time.Millisecond)).UTC(), nil
+}
+
+// parseString parses a string which may be split into chunks, kind is used in the comments.
+//
+// # UTF-8 encoded character string split into 64k chunks
+// ::= x52 b1 b0 <utf8-data> string  # non-final chunk
+// ::= 'S' b1 b0 <utf8-data>         # string of length 0-65535
+// ::= [x00-x1f] <utf8-data>         # string of length 0-31
+// ::= [x30-x34] <utf8-data>         # string of length 0-1023
+func (d *disassembler) parseString(kind string) (string, error) {
+	var (
+		units []uint16
+		chunk int
+	)
+
+	for {
+		start := d.pos
+		tag, err := d.readByte()
+		if err != nil {
+			return "", err
+		}
+
+		var length int
+		switch {
+		case tag <= STRING_DIRECT_MAX:
+			length = int(tag)
+		case tag >= 0x30 && tag <= 0x33:
+			b, err := d.readByte()
+			if err != nil {
+				return "", err
+			}
+			length = int(tag-BC_STRING_SHORT)<<8 + int(b)
+		case tag == BC_STRING || tag == BC_STRING_CHUNK:
+			if length, err = d.readUint16(); err != nil {
+				return "", err
+			}
+		default:
+			return "", perrors.Errorf("expect %s at offset %#x, but got tag %#x", kind, start, tag)
+		}
+
+		chars, err := d.readChars(length)
+		if err != nil {
+			return "", err
+		}
+		units = append(units, chars...)
+		text := quoteText(string(utf16.Decode(chars)))
+
+		if tag == BC_STRING_CHUNK {
+			d.emit(start, "%s chunk #%d (%d chars) %s", kind, chunk, length, text)
+			chunk++
+			continue
+		}
+		if chunk > 0 {
+			d.emit(start, "%s final chunk #%d (%d chars) %s", kind, chunk, length, text)
+		} else {
+			d.emit(start, "%s (%d chars) %s", kind, length, text)
+		}
+		return string(utf16.Decode(units)), nil
+	}
+}
+
+// readChars reads n utf-16 chars, the surrogate pairs are encoded as two 3-byte sequences.
+func (d *disassembler) readChars(n int) ([]uint16, error) {
+	units := make([]uint16, 0, n)
+	for len(units) < n {
+		start := d.pos
+		ch, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case ch < 0x80:
+			units = append(units, uint16(ch))
+
+		case ch&0xe0 == 0xc0:
+			b, err := d.read(1)
+			if err != nil {
+				return nil, err
+			}
+			units = append(units, uint16(ch&0x1f)<<6|uint16(b[0]&0x3f))
+
+		case ch&0xf0 == 0xe0:
+			b, err := d.read(2)
+			if err != nil {
+				return nil, err
+			}
+			units = append(units, uint16(ch&0x0f)<<12|uint16(b[0]&0x3f)<<6|uint16(b[1]&0x3f))
+
+		case ch&0xf8 == 0xf0:
+			// standard utf-8 of a supplementary char, which is counted as two chars
+			b, err := d.read(3)
+			if err != nil {
+				return nil, err
+			}
+			r := rune(ch&0x07)<<18 | rune(b[0]&0x3f)<<12 | rune(b[1]&0x3f)<<6 | rune(b[2]&0x3f)
+			r1, r2 := utf16.EncodeRune(r)
+			units = append(units, uint16(r1), uint16(r2))
+
+		default:
+			return nil, perrors.Errorf("bad utf-8 encoding %#x at offset %#x", ch, start)
+		}
+	}
+	return units, nil
+}
+
+// # 8-bit binary data split into 64k chunks
+// ::= x41 b1 b0 <binary-data> binary # non-final chunk
+// ::= 'B' b1 b0 <binary-data>        # final chunk
+// ::= [x20-x2f] <binary-data>        # binary data of length 0-15
+// ::= [x34-x37] <binary-data>        # binary data of length 0-1023
+func (d *disassembler) parseBinary() ([]byte, error) {
+	var (
+		data  []byte
+		chunk int
+	)
+
+	for {
+		start := d.pos
+		tag, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+
+		var length int
+		switch {
+		case tag >= BC_BINARY_DIRECT && tag <= 0x2f:
+			length = int(tag - BC_BINARY_DIRECT)
+		case tag >= BC_BINARY_SHORT && tag <= 0x37:
+			b, err := d.readByte()
+			if err != nil {
+				return nil, err
+			}
+			length = int(tag-BC_BINARY_SHORT)<<8 + int(b)
+		case tag == BC_BINARY || tag == BC_BINARY_CHUNK:
+			if length, err = d.readUint16(); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, perrors.Errorf("expect binary at offset %#x, but got tag %#x", start, tag)
+		}
+
+		b, err := d.read(length)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, b...)
+
+		if tag == BC_BINARY_CHUNK {
+			d.emit(start, "binary chunk #%d (%d bytes)", chunk, length)
+			chunk++
+			continue
+		}
+		if chunk > 0 {
+			d.emit(start, "binary final chunk #%d (%d bytes)", chunk, length)
+		} else {
+			d.emit(start, "binary (%d bytes)", length)
+		}
+		if data == nil {
+			data = []byte{}
+		}
+		return data, nil
+	}
+}
+
+// parseType parses the type of list or map, which is a string or a ref to a former type.
+func (d *disassembler) parseType() (string, error) {
+	start := d.pos
+	tag, err := d.peekByte()
+	if err != nil {
+		return "", err
+	}
+
+	if tag <= STRING_DIRECT_MAX || (tag >= 0x30 && tag <= 0x33) || tag == BC_STRING || tag == BC_STRING_CHUNK {
+		d.depth++
+		typ, err := d.parseString("type")
+		d.depth--
+		if err != nil {
+			return "", err
+		}
+		d.types = append(d.types, typ)
+		return typ, nil
+	}
+
+	idx, err := d.readIntValue()
+	if err != nil {
+		return "", err
+	}
+	if idx < 0 || int(idx) >= len(d.types) {
+		return "", perrors.Errorf("the type ref index %d at offset %#x is out of range", idx, start)
+	}
+	d.depth++
+	d.emit(start, "type ref #%d %s", idx, d.types[idx])
+	d.depth--
+	return d.types[idx], nil
+}
+
+// ::= x55 type value* 'Z'   # variable-length list
+// ::= 'V' type int value*   # fixed-length list
+// ::= x57 value* 'Z'        # variable-length untyped list
+// ::= x58 int value*        # fixed-length untyped list
+// ::= [x70-77] type value*  # fixed-length typed list
+// ::= [x78-7f] value*       # fixed-length untyped list
+func (d *disassembler) parseList(tag byte, node *ValueNode) error {
+	start := d.pos - 1
+	node.Kind = KindList
+	node.Ref = d.refs
+	d.refs++
+
+	var (
+		err      error
+		length   = -1
+		typed    = tag == BC_LIST_VARIABLE || tag == BC_LIST_FIXED || (tag >= BC_LIST_DIRECT && tag < BC_LIST_DIRECT_UNTYPED)
+		variable = tag == BC_LIST_VARIABLE || tag == BC_LIST_VARIABLE_UNTYPED
+	)
+
+	switch {
+	case tag >= BC_LIST_DIRECT_UNTYPED:
+		length = int(tag - BC_LIST_DIRECT_UNTYPED)
+	case tag >= BC_LIST_DIRECT:
+		length = int(tag - BC_LIST_DIRECT)
+	}
+
+	if variable {
+		d.emit(start, "%s list #%d (variable length)", typedText(typed), node.Ref)
+	} else if length >= 0 {
+		d.emit(start, "%s list #%d (%d items)", typedText(typed), node.Ref, length)
+	} else {
+		d.emit(start, "%s list #%d", typedText(typed), node.Ref)
+	}
+
+	if typed {
+		if node.Type, err = d.parseType(); err != nil {
+			return err
+		}
+	}
+
+	if !variable && length < 0 {
+		lenStart := d.pos
+		n, err := d.readIntValue()
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return perrors.Errorf("illegal list length %d at offset %#x", n, lenStart)
+		}
+		length = int(n)
+		d.depth++
+		d.emit(lenStart, "length %d", length)
+		d.depth--
+	}
+
+	d.depth++
+	for i := 0; variable || i < length; i++ {
+		if variable {
+			b, err := d.peekByte()
+			if err != nil {
+				return err
+			}
+			if b == BC_END {
+				break
+			}
+		}
+		d.label = fmt.Sprintf("[%d]", i)
+		child, err := d.parseValue()
+		if err != nil {
+			return err
+		}
+		node.Children = append(node.Children, child)
+	}
+	d.depth--
+
+	if variable {
+		return d.parseEnd("list", node.Ref)
+	}
+	return nil
+}
+
+// ::= 'M' type (value value)* 'Z'  # key, value map pairs
+// ::= 'H' (value value)* 'Z'       # untyped key, value
+func (d *disassembler) parseMap(tag byte, node *ValueNode) error {
+	var err error
+
+	start := d.pos - 1
+	node.Kind = KindMap
+	node.Ref = d.refs
+	d.refs++
+	d.emit(start, "%s map #%d", typedText(tag == BC_MAP), node.Ref)
+
+	if tag == BC_MAP {
+		if node.Type, err = d.parseType(); err != nil {
+			return err
+		}
+	}
+
+	d.depth++
+	for {
+		b, err := d.peekByte()
+		if err != nil {
+			return err
+		}
+		if b == BC_END {
+			break
+		}
+
+		d.label = "key"
+		key, err := d.parseValue()
+		if err != nil {
+			return err
+		}
+		d.label = "value"
+		if key.Kind == KindString {
+			d.label = key.Value.(string)
+		}
+		value, err := d.parseValue()
+		if err != nil {
+			return err
+		}
+		node.Keys = append(node.Keys, key)
+		node.Children = append(node.Children, value)
+	}
+	d.depth--
+
+	return d.parseEnd("map", node.Ref)
+}
+
+func (d *disassembler) parseEnd(kind string, ref int) error {
+	start := d.pos
+	if _, err := d.readByte(); err != nil {
+		return err
+	}
+	d.emit(start, "end of %s #%d", kind, ref)
+	return nil
+}
+
+// class-def  ::= 'C' string int string*
+func (d *disassembler) parseClassDef() error {
+	start := d.pos
+	d.pos++
+	d.emit(start, "class definition #%d", len(d.classDefs))
+
+	d.depth++
+	defer func() { d.depth-- }()
+
+	name, err := d.parseString("class name")
+	if err != nil {
+		return err
+	}
+
+	countStart := d.pos
+	count, err := d.readIntValue()
+	if err != nil {
+		return err
+	}
+	if count < 0 {
+		return perrors.Errorf("illegal field count %d at offset %#x", count, countStart)
+	}
+	d.emit(countStart, "%d fields", count)
+
+	def := disassembleClassDef{name: name}
+	for i := 0; i < int(count); i++ {
+		field, err := d.parseString(fmt.Sprintf("field #%d", i))
+		if err != nil {
+			return err
+		}
+		def.fields = append(def.fields, field)
+	}
+	d.classDefs = append(d.classDefs, def)
+	return nil
+}
+
+// ::= 'O' int value*
+// ::= [x60-x6f] value*
+func (d *disassembler) parseObject(tag byte, node *ValueNode) error {
+	start := d.pos - 1
+
+	var idx int32
+	if tag == BC_OBJECT {
+		var err error
+		if idx, err = d.readIntValue(); err != nil {
+			return err
+		}
+	} else {
+		idx = int32(tag - BC_OBJECT_DIRECT)
+	}
+	if idx < 0 || int(idx) >= len(d.classDefs) {
+		return perrors.Errorf("illegal class definition index %d at offset %#x", idx, start)
+	}
+
+	def := d.classDefs[idx]
+	node.Kind = KindObject
+	node.Type = def.name
+	node.Fields = def.fields
+	node.Ref = d.refs
+	d.refs++
+	d.emit(start, "object #%d (class def #%d %s)", node.Ref, idx, def.name)
+
+	d.depth++
+	defer func() { d.depth-- }()
+	for _, field := range def.fields {
+		d.label = field
+		child, err := d.parseValue()
+		if err != nil {
+			return err
+		}
+		node.Children = append(node.Children, child)
+	}
+	return nil
+}
+
+func typedText(typed bool) string {
+	if typed {
+		return "typed"
+	}
+	return "untyped"
+}
+
+// quoteText quotes a string for comments, and cuts the long one.
+func quoteText(s string) string {
+	r := []rune(s)
+	if len(r) > instructionTextMax {
+		return strconv.Quote(string(r[:instructionTextMax])) + "..."
+	}
+	return strconv.Quote(s)
+}
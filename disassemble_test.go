@@ -0,0 +1,121 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+type disassembleCar struct {
+	Name   string
+	Wheels []int32
+	Owner  *disassembleCar
+}
+
+func (disassembleCar) JavaClassName() string {
+	return "example.Car"
+}
+
+func TestDisassemble(t *testing.T) {
+	car := &disassembleCar{Name: "tesla", Wheels: []int32{1, 2, 3, 4}}
+	car.Owner = car
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(car))
+	assert.Nil(t, e.Encode(map[interface{}]interface{}{"k": int64(1)}))
+	data := e.Buffer()
+
+	insts, err := Disassemble(data)
+	assert.Nil(t, err)
+
+	var listing []string
+	end := 0
+	for _, inst := range insts {
+		assert.Equal(t, end, inst.Offset)
+		end = inst.Offset + len(inst.Bytes)
+		listing = append(listing, inst.String())
+	}
+	assert.Equal(t, len(data), end)
+
+	text := strings.Join(listing, "\n")
+	t.Log("\n" + text)
+	assert.Contains(t, text, "class definition #0")
+	assert.Contains(t, text, `class name (11 chars) "example.Car"`)
+	assert.Contains(t, text, "object #0 (class def #0 example.Car)")
+	assert.Contains(t, text, `name: string (5 chars) "tesla"`)
+	assert.Contains(t, text, "[3]: int 4")
+	assert.Contains(t, text, "owner: ref to #0")
+	assert.Contains(t, text, "k: long 1")
+	assert.Contains(t, text, "end of map #2")
+}
+
+func TestParseValueTree(t *testing.T) {
+	long := strings.Repeat("a", CHUNK_SIZE+10) + "\U0001F600"
+	now := time.Unix(1600000000, 123000000).UTC()
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(long))
+	assert.Nil(t, e.Encode([]byte{1, 2, 3}))
+	assert.Nil(t, e.Encode(now))
+	assert.Nil(t, e.Encode(1.5))
+	assert.Nil(t, e.Encode([]string{"x", "y"}))
+
+	nodes, err := ParseValueTree(e.Buffer())
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(nodes))
+
+	assert.Equal(t, KindString, nodes[0].Kind)
+	assert.Equal(t, long, nodes[0].Value)
+	assert.Equal(t, KindBinary, nodes[1].Kind)
+	assert.Equal(t, []byte{1, 2, 3}, nodes[1].Value)
+	assert.Equal(t, KindDate, nodes[2].Kind)
+	assert.True(t, now.Equal(nodes[2].Value.(time.Time)))
+	assert.Equal(t, 1.5, nodes[3].Value)
+	assert.Equal(t, KindList, nodes[4].Kind)
+	assert.Equal(t, "[string", nodes[4].Type)
+	assert.Equal(t, 2, len(nodes[4].Children))
+	assert.Equal(t, "y", nodes[4].Children[1].Value)
+
+	insts, err := Disassemble(e.Buffer())
+	assert.Nil(t, err)
+	assert.Contains(t, insts[0].Comment, "string chunk #0")
+	assert.Contains(t, insts[1].Comment, "string final chunk #1 (12 chars)")
+}
+
+func TestDisassembleError(t *testing.T) {
+	e := NewEncoder()
+	assert.Nil(t, e.Encode("hello"))
+	assert.Nil(t, e.Encode("world"))
+	data := e.Buffer()
+
+	insts, err := Disassemble(data[:len(data)-1])
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, len(insts))
+
+	_, err = Disassemble([]byte{BC_REF, 0x90})
+	assert.NotNil(t, err)
+
+	_, err = Disassemble([]byte{0x60})
+	assert.NotNil(t, err)
+}
@@ -18,6 +18,7 @@
 package hessian
 
 import (
+	"math/big"
 	"reflect"
 	"time"
 	"unsafe"
@@ -27,6 +28,10 @@ import (
 	perrors "github.com/pkg/errors"
 )
 
+import (
+	"github.com/apache/dubbo-go-hessian2/java8_time"
+)
+
 // nil bool int8 int32 int64 float32 float64 time.Time
 // string []byte []interface{} map[interface{}]interface{}
 // array object struct
@@ -36,6 +41,18 @@ type Encoder struct {
 	classInfoList []*ClassInfo
 	buffer        []byte
 	refMap        map[unsafe.Pointer]_refElem
+
+	// TimeType is the java type which time.Time is encoded as, it can be overridden by the "time" option of the field tag.
+	TimeType JavaTimeType
+	// DurationType is the java type which time.Duration is encoded as, it can be overridden by the "duration" option of the field tag.
+	DurationType JavaDurationType
+	// Java8TimeFormat is the wire format of the java8_time types.
+	Java8TimeFormat Java8TimeFormat
+	// UUIDFormat is the layout which java_util.UUID is encoded in.
+	UUIDFormat UUIDFormat
+	// If Uint64AsBigInteger is true, the uint64 values greater than math.MaxInt64 are encoded as java.math.BigInteger,
+	// instead of the negative longs of the same bits.
+	Uint64AsBigInteger bool
 }
 
 // classIndex find the index of the given java name in encoder class info list.
@@ -130,20 +147,18 @@ func (e *Encoder) Encode(v interface{}) error {
 		// when decode
 		e.buffer = encInt64(e.buffer, int64(val))
 	case uint:
-		e.buffer = encInt64(e.buffer, int64(val))
+		return e.encUint64(uint64(val))
 
 	case int64:
 		e.buffer = encInt64(e.buffer, val)
 	case uint64:
-		e.buffer = encInt64(e.buffer, int64(val))
+		return e.encUint64(val)
 
 	case time.Time:
-		if ZeroDate == val {
-			e.buffer = EncNull(e.buffer)
-		} else {
-			e.buffer = encDateInMs(e.buffer, &val)
-			// e.buffer = encDateInMimute(v.(time.Time), e.buffer)
-		}
+		return e.encTime(val, e.TimeType)
+
+	case time.Duration:
+		return e.encDuration(val, e.DurationType)
 
 	case float32:
 		e.buffer = encFloat32(e.buffer, val)
@@ -154,12 +169,59 @@ func (e *Encoder) Encode(v interface{}) error {
 	case string:
 		e.buffer = encString(e.buffer, val)
 
+	case JavaChar:
+		e.buffer = encJavaChars(e.buffer, []JavaChar{val})
+
+	case []JavaChar:
+		if val == nil {
+			e.buffer = EncNull(e.buffer)
+			return nil
+		}
+		e.buffer = encJavaChars(e.buffer, val)
+
 	case []byte:
 		e.buffer = encBinary(e.buffer, val)
 
 	case map[interface{}]interface{}:
 		return e.encUntypedMap(val)
 
+	case *OrderedMap:
+		return e.encOrderedMap(val)
+
+	case OrderedMap:
+		return e.encOrderedMap(&val)
+
+	case *big.Int:
+		return e.encBigInt(val)
+	case big.Int:
+		return e.encBigInt(&val)
+
+	case *big.Rat:
+		return e.encBigRat(val)
+	case big.Rat:
+		return e.encBigRat(&val)
+
+	case *big.Float:
+		return e.encBigFloat(val)
+	case big.Float:
+		return e.encBigFloat(&val)
+
+	case java8_time.DayOfWeek:
+		e.encEnumName(val.JavaClassName(), val.String())
+
+	case java8_time.Month:
+		e.encEnumName(val.JavaClassName(), val.String())
+
+	case JavaEnumValue:
+		e.encEnumName(val.Class, val.Name)
+
+	case *JavaEnumValue:
+		if val == nil {
+			e.buffer = EncNull(e.buffer)
+			return nil
+		}
+		e.encEnumName(val.Class, val.Name)
+
 	case POJOEnum:
 		if p, ok := v.(POJOEnum); ok {
 			return e.encObject(p)
@@ -179,9 +241,8 @@ func (e *Encoder) Encode(v interface{}) error {
 				e.buffer = EncNull(e.buffer)
 				return nil
 			}
-			if vv.Type().String() == "time.Time" {
-				e.buffer = encDateInMs(e.buffer, v)
-				return nil
+			if vv.Type() == timeType {
+				return e.encTime(vv.Interface().(time.Time), e.TimeType)
 			}
 			if p, ok := v.(POJO); ok {
 				var clazz string
@@ -214,9 +275,17 @@ func (e *Encoder) Encode(v interface{}) error {
 			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
 			reflect.Float32, reflect.Float64: // resolve base type
 			vVal := reflect.ValueOf(v)
-			if reflect.Ptr == vVal.Kind() && !vVal.IsNil() {
+			if reflect.Ptr == vVal.Kind() {
+				if vVal.IsNil() {
+					e.buffer = EncNull(e.buffer)
+					return nil
+				}
 				return e.Encode(vVal.Elem().Interface())
 			}
+			if p, ok := v.(POJO); ok && t.Kind() == reflect.String {
+				// the java enum of go string type
+				e.encEnumName(p.JavaClassName(), vVal.String())
+			}
 		default:
 			return perrors.Errorf("type not supported! %s", t.Kind().String())
 		}
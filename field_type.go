@@ -0,0 +1,243 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"math"
+	"reflect"
+	"strings"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+// the tag option to override the java type of the field, e.g. `hessian:"items,type=java.util.ArrayList"`.
+// The slices and arrays are encoded as the typed lists of the type, and "short[]" is the same as "[short",
+// the maps are encoded as the typed maps of the type, and the other values are converted to the java primitive types.
+const typeTagOption = "type"
+
+// javaPrimitiveType is the java primitive type, the wrapper class is the same one.
+type javaPrimitiveType int
+
+const (
+	javaBoolean javaPrimitiveType = iota
+	javaByte
+	javaShort
+	javaInt
+	javaLong
+	javaFloat
+	javaDouble
+	javaChar
+	javaString
+)
+
+var javaPrimitiveTypes = map[string]javaPrimitiveType{
+	"boolean":             javaBoolean,
+	"java.lang.Boolean":   javaBoolean,
+	"byte":                javaByte,
+	"java.lang.Byte":      javaByte,
+	"short":               javaShort,
+	"java.lang.Short":     javaShort,
+	"int":                 javaInt,
+	"java.lang.Integer":   javaInt,
+	"long":                javaLong,
+	"java.lang.Long":      javaLong,
+	"float":               javaFloat,
+	"java.lang.Float":     javaFloat,
+	"double":              javaDouble,
+	"java.lang.Double":    javaDouble,
+	"char":                javaChar,
+	"java.lang.Character": javaChar,
+	"java.lang.String":    javaString,
+}
+
+// encField encodes the value of a struct field, the java type of the field can be chosen by the tag.
+func (e *Encoder) encField(tag fieldTag, field reflect.Value) error {
+	if javaType, ok := tag.option(typeTagOption); ok {
+		return e.encFieldAs(field, javaType)
+	}
+
+	fieldType := UnpackPtrType(field.Type())
+	if fieldType == timeType || fieldType == durationType {
+		return e.encTimeField(tag, field)
+	}
+	return e.Encode(field.Interface())
+}
+
+// checkFieldTypes checks the type tag options of the fields of the struct type.
+func checkFieldTypes(typ reflect.Type) error {
+	typ = UnpackPtrType(typ)
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		structField := typ.Field(i)
+		if structField.PkgPath != "" {
+			continue
+		}
+		tag := parseFieldTag(structField)
+		if tag.ignored {
+			continue
+		}
+		if structField.Anonymous && structField.Type.Kind() == reflect.Struct {
+			if err := checkFieldTypes(structField.Type); err != nil {
+				return err
+			}
+			continue
+		}
+		if javaType, ok := tag.option(typeTagOption); ok {
+			if err := checkFieldType(structField.Type, javaType); err != nil {
+				return perrors.Wrapf(err, "field %s.%s", typ.Name(), structField.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// checkFieldType checks whether the field of the go type can be encoded as the java type.
+func checkFieldType(typ reflect.Type, javaType string) error {
+	typ = UnpackPtrType(typ)
+	switch typ.Kind() {
+	case reflect.Interface:
+		// checked when encoding
+		return nil
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if javaType == "" {
+			return perrors.Errorf("empty java type of %s", typ)
+		}
+		return nil
+	}
+
+	primitive, ok := javaPrimitiveTypes[javaType]
+	if !ok {
+		return perrors.Errorf("%s can not be encoded as %s", typ, javaType)
+	}
+	kind := typ.Kind()
+	switch primitive {
+	case javaBoolean:
+		ok = kind == reflect.Bool
+	case javaString:
+		ok = kind == reflect.String
+	case javaFloat, javaDouble:
+		ok = validateIntKind(kind) || validateUintKind(kind) || validateFloatKind(kind)
+	default:
+		ok = validateIntKind(kind) || validateUintKind(kind)
+	}
+	if !ok {
+		return perrors.Errorf("%s can not be encoded as %s", typ, javaType)
+	}
+	return nil
+}
+
+// encFieldAs encodes the field value as the java type of the type tag option.
+func (e *Encoder) encFieldAs(field reflect.Value, javaType string) error {
+	v := UnpackPtrValue(field)
+	if v.Kind() == reflect.Interface {
+		v = UnpackPtrValue(v.Elem())
+	}
+	if !v.IsValid() || v.Kind() == reflect.Ptr || (v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.IsNil() {
+		e.buffer = EncNull(e.buffer)
+		return nil
+	}
+	if err := checkFieldType(v.Type(), javaType); err != nil {
+		return err
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if strings.HasSuffix(javaType, "[]") {
+			javaType = "[" + strings.TrimSuffix(javaType, "[]")
+		}
+		return e.writeTypedListOf(v.Interface(), javaType)
+	case reflect.Map:
+		return e.encTypedMap(v.Interface(), javaType)
+	}
+
+	switch javaPrimitiveTypes[javaType] {
+	case javaBoolean:
+		e.buffer = encBool(e.buffer, v.Bool())
+	case javaString:
+		e.buffer = encString(e.buffer, v.String())
+	case javaFloat:
+		e.buffer = encFloat32(e.buffer, float32(floatValue(v)))
+	case javaDouble:
+		e.buffer = encFloat(e.buffer, floatValue(v))
+	case javaByte:
+		return e.encIntAs(v, javaType, math.MinInt8, math.MaxInt8)
+	case javaShort:
+		return e.encIntAs(v, javaType, math.MinInt16, math.MaxInt16)
+	case javaInt:
+		return e.encIntAs(v, javaType, math.MinInt32, math.MaxInt32)
+	case javaLong:
+		return e.encIntAs(v, javaType, math.MinInt64, math.MaxInt64)
+	case javaChar:
+		return e.encIntAs(v, javaType, 0, math.MaxUint16)
+	}
+	return nil
+}
+
+// encIntAs encodes the integer value as the java integer type in the range.
+func (e *Encoder) encIntAs(v reflect.Value, javaType string, min, max int64) error {
+	var n int64
+	if validateUintKind(v.Kind()) {
+		if v.Uint() > uint64(max) {
+			return perrors.Errorf("%d overflows %s", v.Uint(), javaType)
+		}
+		n = int64(v.Uint())
+	} else {
+		n = v.Int()
+	}
+	if n < min || n > max {
+		return perrors.Errorf("%d overflows %s", n, javaType)
+	}
+
+	switch {
+	case javaPrimitiveTypes[javaType] == javaChar:
+		e.buffer = encJavaChars(e.buffer, []JavaChar{JavaChar(n)})
+	case max == math.MaxInt64:
+		e.buffer = encInt64(e.buffer, n)
+	default:
+		e.buffer = encInt32(e.buffer, int32(n))
+	}
+	return nil
+}
+
+func floatValue(v reflect.Value) float64 {
+	switch {
+	case validateIntKind(v.Kind()):
+		return float64(v.Int())
+	case validateUintKind(v.Kind()):
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
+
+// isJavaCharField checks whether the field is a JavaChar or an integer of the "char" type tag option.
+func isJavaCharField(field reflect.StructField) bool {
+	typ := UnpackPtrType(field.Type)
+	if typ == javaCharType {
+		return true
+	}
+	if !validateIntKind(typ.Kind()) && !validateUintKind(typ.Kind()) {
+		return false
+	}
+	javaType, ok := parseFieldTag(field).option(typeTagOption)
+	return ok && javaPrimitiveTypes[javaType] == javaChar
+}
@@ -0,0 +1,106 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+type typedFieldHolder struct {
+	Age    int32            `hessian:"age,type=short"`
+	Ratio  float64          `hessian:"ratio,type=float"`
+	Count  int              `hessian:"count,type=int"`
+	Grade  uint16           `hessian:"grade,type=char"`
+	Items  []string         `hessian:"items,type=java.util.ArrayList"`
+	Codes  []int32          `hessian:"codes,type=short[]"`
+	Scores map[string]int32 `hessian:"scores,type=java.util.TreeMap"`
+	Extra  interface{}      `hessian:"extra,type=long"`
+	None   []string         `hessian:"none,type=java.util.ArrayList"`
+}
+
+func (typedFieldHolder) JavaClassName() string {
+	return "test.model.TypedFieldHolder"
+}
+
+type invalidTypedFieldHolder struct {
+	Name string `hessian:"name,type=int"`
+}
+
+func (invalidTypedFieldHolder) JavaClassName() string {
+	return "test.model.InvalidTypedFieldHolder"
+}
+
+func TestFieldTypeOption(t *testing.T) {
+	RegisterPOJO(&typedFieldHolder{})
+
+	holder := &typedFieldHolder{
+		Age:    30,
+		Ratio:  0.123456789,
+		Count:  7,
+		Grade:  'A',
+		Items:  []string{"a", "b"},
+		Codes:  []int32{1, 2},
+		Scores: map[string]int32{"x": 1},
+		Extra:  int32(5),
+	}
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(holder))
+
+	nodes, err := ParseValueTree(e.Buffer())
+	assert.Nil(t, err)
+	fields := nodes[0].Children
+	assert.Equal(t, KindInt, fields[0].Kind)
+	assert.Equal(t, KindDouble, fields[1].Kind)
+	assert.Equal(t, KindInt, fields[2].Kind)
+	assert.Equal(t, KindString, fields[3].Kind)
+	assert.Equal(t, "java.util.ArrayList", fields[4].Type)
+	assert.Equal(t, "[short", fields[5].Type)
+	assert.Equal(t, "java.util.TreeMap", fields[6].Type)
+	assert.Equal(t, KindLong, fields[7].Kind)
+	assert.Equal(t, KindNull, fields[8].Kind)
+
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	h := res.(*typedFieldHolder)
+	assert.Equal(t, holder.Age, h.Age)
+	// the value is rounded to float
+	assert.Equal(t, 0.12345679, h.Ratio)
+	assert.Equal(t, holder.Count, h.Count)
+	assert.Equal(t, holder.Grade, h.Grade)
+	assert.Equal(t, holder.Items, h.Items)
+	assert.Equal(t, holder.Codes, h.Codes)
+	assert.Equal(t, holder.Scores, h.Scores)
+	assert.Equal(t, int64(5), h.Extra)
+
+	// out of range
+	holder.Age = 1 << 16
+	assert.NotNil(t, NewEncoder().Encode(holder))
+
+	// the type doesn't match the field
+	assert.Equal(t, -1, RegisterPOJO(&invalidTypedFieldHolder{}))
+	_, err = TryRegisterPOJO(&invalidTypedFieldHolder{})
+	assert.NotNil(t, err)
+	assert.Equal(t, "field invalidTypedFieldHolder.Name: string can not be encoded as int", err.Error())
+	err = NewEncoder().Encode(&invalidTypedFieldHolder{Name: "x"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalidTypedFieldHolder.Name")
+}
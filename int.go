@@ -112,7 +112,7 @@ func (d *Decoder) decInt32(flag int32) (int32, error) {
 
 func (e *Encoder) encTypeInt32(b []byte, p interface{}) ([]byte, error) {
 	value := reflect.ValueOf(p)
-	if PackPtr(value).IsNil() {
+	if value.Kind() == reflect.Ptr && value.IsNil() {
 		return EncNull(b), nil
 	}
 	value = UnpackPtrValue(value)
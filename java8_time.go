@@ -17,22 +17,31 @@
 
 package hessian
 
+import (
+	"reflect"
+)
+
 import (
 	"github.com/apache/dubbo-go-hessian2/java8_time"
 )
 
 func init() {
-	RegisterPOJO(&java8_time.Year{Year: 2020})
-	RegisterPOJO(&java8_time.YearMonth{Month: 2020, Year: 6})
-	RegisterPOJO(&java8_time.Period{Years: 2020, Months: 6, Days: 16})
-	RegisterPOJO(&java8_time.LocalDate{Year: 2020, Month: 6, Day: 16})
-	RegisterPOJO(&java8_time.LocalTime{Hour: 6, Minute: 5, Second: 4, Nano: 3})
-	RegisterPOJO(&java8_time.LocalDateTime{Date: java8_time.LocalDate{Year: 2020, Month: 6, Day: 16}, Time: java8_time.LocalTime{Hour: 6, Minute: 5, Second: 4, Nano: 3}})
-	RegisterPOJO(&java8_time.MonthDay{Month: 6, Day: 16})
-	RegisterPOJO(&java8_time.Duration{Seconds: 30, Nanos: 10})
-	RegisterPOJO(&java8_time.Instant{Seconds: 100, Nanos: 10})
-	RegisterPOJO(&java8_time.ZoneOffSet{Seconds: 7200})
-	RegisterPOJO(&java8_time.OffsetDateTime{DateTime: java8_time.LocalDateTime{Date: java8_time.LocalDate{Year: 2020, Month: 6, Day: 16}, Time: java8_time.LocalTime{Hour: 6, Minute: 5, Second: 4, Nano: 3}}, Offset: java8_time.ZoneOffSet{Seconds: 7200}})
-	RegisterPOJO(&java8_time.OffsetTime{LocalTime: java8_time.LocalTime{Hour: 6, Minute: 5, Second: 4, Nano: 3}, ZoneOffset: java8_time.ZoneOffSet{Seconds: 7200}})
-	RegisterPOJO(&java8_time.ZonedDateTime{DateTime: java8_time.LocalDateTime{Date: java8_time.LocalDate{Year: 2020, Month: 6, Day: 16}, Time: java8_time.LocalTime{Hour: 6, Minute: 5, Second: 4, Nano: 3}}, Offset: java8_time.ZoneOffSet{Seconds: 7200}, ZoneId: "Z"})
+	for typ, c := range java8TimeClasses {
+		o := reflect.New(typ).Interface()
+		// the class of hessian-lite is registered at last, so that it's the class of the go type
+		RegisterPOJOMapping(c.className(Java8TimeDubbo3), o)
+		if c.caucho != "" {
+			RegisterPOJOMapping(c.caucho, o)
+			SetSerializer(c.caucho, Java8TimeSerializer{})
+		}
+		RegisterPOJO(o.(POJO))
+		SetSerializer(c.className(Java8TimeHessianLite), Java8TimeSerializer{})
+		SetSerializer(c.className(Java8TimeDubbo3), Java8TimeSerializer{})
+	}
+
+	RegisterPOJO(&java8_time.ZoneRegion{})
+	for _, o := range []POJO{java8_time.DayOfWeek(0), java8_time.Month(0)} {
+		RegisterPOJOMapping(o.JavaClassName(), o)
+		SetSerializer(o.JavaClassName(), Java8EnumSerializer{})
+	}
 }
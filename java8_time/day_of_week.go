@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package java8_time
+
+import (
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+// DayOfWeek is the java enum java.time.DayOfWeek, the value is the same as DayOfWeek.getValue() of java.
+type DayOfWeek int32
+
+const (
+	Monday DayOfWeek = iota + 1
+	Tuesday
+	Wednesday
+	Thursday
+	Friday
+	Saturday
+	Sunday
+)
+
+var dayOfWeekNames = []string{"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY", "SUNDAY"}
+
+// DayOfWeekOf returns the DayOfWeek of the go weekday.
+func DayOfWeekOf(d time.Weekday) DayOfWeek {
+	if d == time.Sunday {
+		return Sunday
+	}
+	return DayOfWeek(d)
+}
+
+// ParseDayOfWeek returns the DayOfWeek of the java enum name.
+func ParseDayOfWeek(name string) (DayOfWeek, error) {
+	for i, n := range dayOfWeekNames {
+		if n == name {
+			return DayOfWeek(i + 1), nil
+		}
+	}
+	return 0, perrors.Errorf("invalid day of week: %s", name)
+}
+
+func (DayOfWeek) JavaClassName() string {
+	return "java.time.DayOfWeek"
+}
+
+// String returns the java enum name.
+func (d DayOfWeek) String() string {
+	if d < Monday || d > Sunday {
+		return ""
+	}
+	return dayOfWeekNames[d-1]
+}
+
+// ToStd returns the go weekday.
+func (d DayOfWeek) ToStd() time.Weekday {
+	return time.Weekday(d % 7)
+}
@@ -17,6 +17,15 @@
 
 package java8_time
 
+import (
+	"math"
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
 type Duration struct {
 	Seconds int64 `hessian:"seconds"`
 	Nanos   int32 `hessian:"nanos"`
@@ -29,3 +38,45 @@ func (Duration) JavaClassName() string {
 func (Duration) Error() string {
 	return "encode Duration error"
 }
+
+// DurationFromStd creates a Duration of the go duration, the nanos is always positive like java,
+// so the negative duration has negative seconds and positive nanos, e.g. -1ns is -1s + 999999999ns.
+func DurationFromStd(d time.Duration) Duration {
+	seconds := int64(d / time.Second)
+	nanos := int32(d % time.Second)
+	if nanos < 0 {
+		seconds--
+		nanos += nanosPerSecond
+	}
+	return Duration{Seconds: seconds, Nanos: nanos}
+}
+
+// ToStd converts the duration to a go duration, which fails if the duration is out of the range of go duration.
+func (d Duration) ToStd() (time.Duration, error) {
+	if err := d.Validate(); err != nil {
+		return 0, err
+	}
+	const (
+		maxSeconds = math.MaxInt64 / int64(time.Second)
+		maxNanos   = math.MaxInt64 % int64(time.Second)
+		minSeconds = math.MinInt64/int64(time.Second) - 1
+		minNanos   = math.MinInt64%int64(time.Second) + int64(time.Second)
+	)
+	if d.Seconds > maxSeconds || (d.Seconds == maxSeconds && int64(d.Nanos) > maxNanos) ||
+		d.Seconds < minSeconds || (d.Seconds == minSeconds && int64(d.Nanos) < minNanos) {
+		return 0, perrors.Errorf("duration of %d seconds overflows go duration", d.Seconds)
+	}
+	if d.Seconds < 0 {
+		// avoid the overflow of the seconds of the min duration
+		return time.Duration(d.Seconds+1)*time.Second + time.Duration(d.Nanos) - time.Second, nil
+	}
+	return time.Duration(d.Seconds)*time.Second + time.Duration(d.Nanos), nil
+}
+
+// Validate checks the range of the nanos.
+func (d Duration) Validate() error {
+	if d.Nanos < 0 || d.Nanos >= nanosPerSecond {
+		return perrors.Errorf("invalid nanos of duration: %d", d.Nanos)
+	}
+	return nil
+}
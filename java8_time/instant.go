@@ -17,6 +17,16 @@
 
 package java8_time
 
+import (
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+const nanosPerSecond int32 = 1e9
+
 type Instant struct {
 	Seconds int64 `hessian:"seconds"`
 	Nanos   int32 `hessian:"nanos"`
@@ -29,3 +39,22 @@ func (Instant) JavaClassName() string {
 func (Instant) Error() string {
 	return "encode Instant error"
 }
+
+// InstantFromTime creates an Instant of the time, the nanos is always positive like java,
+// so the instant before 1970 has negative seconds and positive nanos.
+func InstantFromTime(t time.Time) Instant {
+	return Instant{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())}
+}
+
+// Time returns the instant as a go time in UTC.
+func (i Instant) Time() time.Time {
+	return time.Unix(i.Seconds, int64(i.Nanos)).UTC()
+}
+
+// Validate checks the range of the nanos.
+func (i Instant) Validate() error {
+	if i.Nanos < 0 || i.Nanos >= nanosPerSecond {
+		return perrors.Errorf("invalid nanos of instant: %d", i.Nanos)
+	}
+	return nil
+}
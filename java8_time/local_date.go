@@ -17,6 +17,14 @@
 
 package java8_time
 
+import (
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
 type LocalDate struct {
 	Year  int32 `hessian:"year"`
 	Month int32 `hessian:"month"`
@@ -30,3 +38,31 @@ func (LocalDate) JavaClassName() string {
 func (LocalDate) Error() string {
 	return "encode LocalDate error"
 }
+
+// NewLocalDate creates a LocalDate, which fails if the date is invalid.
+func NewLocalDate(year, month, day int32) (LocalDate, error) {
+	d := LocalDate{Year: year, Month: month, Day: day}
+	return d, d.Validate()
+}
+
+// LocalDateOf returns the date of the time in its location.
+func LocalDateOf(t time.Time) LocalDate {
+	return LocalDate{Year: int32(t.Year()), Month: int32(t.Month()), Day: int32(t.Day())}
+}
+
+// In returns the start of the date in the location.
+func (d LocalDate) In(loc *time.Location) time.Time {
+	return time.Date(int(d.Year), time.Month(d.Month), int(d.Day), 0, 0, 0, 0, loc)
+}
+
+// Validate checks the month and the day of the month.
+func (d LocalDate) Validate() error {
+	if d.Month < 1 || d.Month > 12 {
+		return perrors.Errorf("invalid month of date: %d", d.Month)
+	}
+	// the day is normalized by go if it's out of the month
+	if d.Day < 1 || d.In(time.UTC).Day() != int(d.Day) {
+		return perrors.Errorf("invalid day of date %d-%02d: %d", d.Year, d.Month, d.Day)
+	}
+	return nil
+}
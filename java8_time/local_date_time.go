@@ -17,6 +17,10 @@
 
 package java8_time
 
+import (
+	"time"
+)
+
 type LocalDateTime struct {
 	Date LocalDate `hessian:"date"`
 	Time LocalTime `hessian:"time"`
@@ -29,3 +33,22 @@ func (LocalDateTime) JavaClassName() string {
 func (LocalDateTime) Error() string {
 	return "encode LocalDateTime error"
 }
+
+// LocalDateTimeOf returns the date and the clock of the time in its location.
+func LocalDateTimeOf(t time.Time) LocalDateTime {
+	return LocalDateTime{Date: LocalDateOf(t), Time: LocalTimeOf(t)}
+}
+
+// In returns the time of the date time in the location.
+func (dt LocalDateTime) In(loc *time.Location) time.Time {
+	return time.Date(int(dt.Date.Year), time.Month(dt.Date.Month), int(dt.Date.Day),
+		int(dt.Time.Hour), int(dt.Time.Minute), int(dt.Time.Second), int(dt.Time.Nano), loc)
+}
+
+// Validate checks the date and the time.
+func (dt LocalDateTime) Validate() error {
+	if err := dt.Date.Validate(); err != nil {
+		return err
+	}
+	return dt.Time.Validate()
+}
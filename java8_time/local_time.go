@@ -17,6 +17,14 @@
 
 package java8_time
 
+import (
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
 type LocalTime struct {
 	Hour   int32 `hessian:"hour"`
 	Minute int32 `hessian:"minute"`
@@ -31,3 +39,29 @@ func (LocalTime) JavaClassName() string {
 func (LocalTime) Error() string {
 	return "encode LocalTime error"
 }
+
+// NewLocalTime creates a LocalTime, which fails if the time is invalid.
+func NewLocalTime(hour, minute, second, nano int32) (LocalTime, error) {
+	t := LocalTime{Hour: hour, Minute: minute, Second: second, Nano: nano}
+	return t, t.Validate()
+}
+
+// LocalTimeOf returns the clock of the time in its location.
+func LocalTimeOf(t time.Time) LocalTime {
+	return LocalTime{Hour: int32(t.Hour()), Minute: int32(t.Minute()), Second: int32(t.Second()), Nano: int32(t.Nanosecond())}
+}
+
+// SinceMidnight returns the duration since the midnight.
+func (t LocalTime) SinceMidnight() time.Duration {
+	return time.Duration(t.Hour)*time.Hour + time.Duration(t.Minute)*time.Minute +
+		time.Duration(t.Second)*time.Second + time.Duration(t.Nano)
+}
+
+// Validate checks the range of the fields.
+func (t LocalTime) Validate() error {
+	if t.Hour < 0 || t.Hour > 23 || t.Minute < 0 || t.Minute > 59 || t.Second < 0 || t.Second > 59 ||
+		t.Nano < 0 || t.Nano >= nanosPerSecond {
+		return perrors.Errorf("invalid time %02d:%02d:%02d.%09d", t.Hour, t.Minute, t.Second, t.Nano)
+	}
+	return nil
+}
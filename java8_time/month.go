@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package java8_time
+
+import (
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+// Month is the java enum java.time.Month, the value is the same as Month.getValue() of java and time.Month of go.
+type Month int32
+
+const (
+	January Month = iota + 1
+	February
+	March
+	April
+	May
+	June
+	July
+	August
+	September
+	October
+	November
+	December
+)
+
+var monthNames = []string{
+	"JANUARY", "FEBRUARY", "MARCH", "APRIL", "MAY", "JUNE",
+	"JULY", "AUGUST", "SEPTEMBER", "OCTOBER", "NOVEMBER", "DECEMBER",
+}
+
+// ParseMonth returns the Month of the java enum name.
+func ParseMonth(name string) (Month, error) {
+	for i, n := range monthNames {
+		if n == name {
+			return Month(i + 1), nil
+		}
+	}
+	return 0, perrors.Errorf("invalid month: %s", name)
+}
+
+func (Month) JavaClassName() string {
+	return "java.time.Month"
+}
+
+// String returns the java enum name.
+func (m Month) String() string {
+	if m < January || m > December {
+		return ""
+	}
+	return monthNames[m-1]
+}
+
+// ToStd returns the go month.
+func (m Month) ToStd() time.Month {
+	return time.Month(m)
+}
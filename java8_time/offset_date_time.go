@@ -17,6 +17,10 @@
 
 package java8_time
 
+import (
+	"time"
+)
+
 type OffsetDateTime struct {
 	DateTime LocalDateTime `hessian:"dateTime"`
 	Offset   ZoneOffSet    `hessian:"offset"`
@@ -29,3 +33,21 @@ func (OffsetDateTime) JavaClassName() string {
 func (OffsetDateTime) Error() string {
 	return "encode OffsetDateTime error"
 }
+
+// OffsetDateTimeOf returns the date time and the offset of the time in its location.
+func OffsetDateTimeOf(t time.Time) OffsetDateTime {
+	return OffsetDateTime{DateTime: LocalDateTimeOf(t), Offset: ZoneOffsetOf(t)}
+}
+
+// Time returns the time in the fixed location of the offset.
+func (dt OffsetDateTime) Time() time.Time {
+	return dt.DateTime.In(dt.Offset.Location())
+}
+
+// Validate checks the date time and the offset.
+func (dt OffsetDateTime) Validate() error {
+	if err := dt.DateTime.Validate(); err != nil {
+		return err
+	}
+	return dt.Offset.Validate()
+}
@@ -0,0 +1,99 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package java8_time
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ZoneId is java.time.ZoneId, which is written as a ZoneIdHandle object of the zone id.
+type ZoneId struct {
+	ZoneId string `hessian:"zoneId"`
+}
+
+// NewZoneId creates a ZoneId of the location, the local location is resolved to its IANA name if it's known,
+// otherwise to the current offset id.
+func NewZoneId(loc *time.Location) *ZoneId {
+	return &ZoneId{ZoneId: ZoneIDOf(time.Now().In(loc))}
+}
+
+func (ZoneId) JavaClassName() string {
+	return "com.alibaba.com.caucho.hessian.io.java8.ZoneIdHandle"
+}
+
+// Location loads the location of the zone id from the tz database,
+// the offset ids like "Z", "+08:00" and "UTC+8" are converted to fixed locations.
+func (z ZoneId) Location() (*time.Location, error) {
+	id := z.ZoneId
+	for _, prefix := range []string{"UTC", "GMT", "UT"} {
+		if strings.HasPrefix(id, prefix) && len(id) > len(prefix) {
+			id = id[len(prefix):]
+			break
+		}
+	}
+	if offset, err := ParseZoneOffset(id); err == nil {
+		return offset.Location(), nil
+	}
+	return time.LoadLocation(z.ZoneId)
+}
+
+var (
+	localZoneOnce sync.Once
+	localZoneName string
+)
+
+// ZoneIDOf returns the java zone id of the location of the time. The local location is named "Local" in go,
+// which is unknown to java, so its IANA name is used, or the offset id of the time if the name is unknown.
+func ZoneIDOf(t time.Time) string {
+	loc := t.Location()
+	if loc == time.Local {
+		if name := localZone(); name != "" {
+			return name
+		}
+		return ZoneOffsetOf(t).ID()
+	}
+	if id := loc.String(); id != "" {
+		return id
+	}
+	return ZoneOffsetOf(t).ID()
+}
+
+// localZone finds the IANA name of the local location like go, from the TZ environment or /etc/localtime.
+func localZone() string {
+	localZoneOnce.Do(func() {
+		name, ok := os.LookupEnv("TZ")
+		if !ok {
+			name, _ = os.Readlink("/etc/localtime")
+		}
+		name = strings.TrimPrefix(name, ":")
+		if i := strings.LastIndex(name, "zoneinfo/"); i >= 0 {
+			name = name[i+len("zoneinfo/"):]
+		}
+		if name == "" || name == "Local" || filepath.IsAbs(name) {
+			return
+		}
+		if _, err := time.LoadLocation(name); err == nil {
+			localZoneName = name
+		}
+	})
+	return localZoneName
+}
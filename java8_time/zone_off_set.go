@@ -17,6 +17,17 @@
 
 package java8_time
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
 type ZoneOffSet struct {
 	Seconds int32 `hessian:"seconds"`
 }
@@ -28,3 +39,72 @@ func (ZoneOffSet) JavaClassName() string {
 func (ZoneOffSet) Error() string {
 	return "encode ZoneOffSet error"
 }
+
+// the max offset of java ZoneOffset is 18 hours.
+const maxOffsetSeconds = 18 * 3600
+
+// ZoneOffsetOf returns the offset of the time in its location.
+func ZoneOffsetOf(t time.Time) ZoneOffSet {
+	_, offset := t.Zone()
+	return ZoneOffSet{Seconds: int32(offset)}
+}
+
+// ParseZoneOffset parses the offset id like java, e.g. "Z", "+8", "+08:00" and "-03:30:15".
+func ParseZoneOffset(id string) (ZoneOffSet, error) {
+	if id == "Z" {
+		return ZoneOffSet{}, nil
+	}
+	if len(id) < 2 || (id[0] != '+' && id[0] != '-') {
+		return ZoneOffSet{}, perrors.Errorf("invalid zone offset id: %s", id)
+	}
+
+	var seconds int64
+	parts := strings.Split(id[1:], ":")
+	if len(parts) > 3 {
+		return ZoneOffSet{}, perrors.Errorf("invalid zone offset id: %s", id)
+	}
+	for i, part := range parts {
+		n, err := strconv.ParseInt(part, 10, 32)
+		if err != nil || n < 0 || (i > 0 && n > 59) {
+			return ZoneOffSet{}, perrors.Errorf("invalid zone offset id: %s", id)
+		}
+		seconds += n * []int64{3600, 60, 1}[i]
+	}
+	if id[0] == '-' {
+		seconds = -seconds
+	}
+	offset := ZoneOffSet{Seconds: int32(seconds)}
+	return offset, offset.Validate()
+}
+
+// ID returns the id of the offset like java, e.g. "Z", "+08:00" and "-03:30:15".
+func (z ZoneOffSet) ID() string {
+	if z.Seconds == 0 {
+		return "Z"
+	}
+	sign, s := '+', z.Seconds
+	if s < 0 {
+		sign, s = '-', -s
+	}
+	id := fmt.Sprintf("%c%02d:%02d", sign, s/3600, s/60%60)
+	if s%60 != 0 {
+		id += fmt.Sprintf(":%02d", s%60)
+	}
+	return id
+}
+
+// Location returns a fixed location of the offset.
+func (z ZoneOffSet) Location() *time.Location {
+	if z.Seconds == 0 {
+		return time.UTC
+	}
+	return time.FixedZone(z.ID(), int(z.Seconds))
+}
+
+// Validate checks the range of the offset.
+func (z ZoneOffSet) Validate() error {
+	if z.Seconds < -maxOffsetSeconds || z.Seconds > maxOffsetSeconds {
+		return perrors.Errorf("invalid zone offset seconds: %d", z.Seconds)
+	}
+	return nil
+}
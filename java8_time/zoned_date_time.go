@@ -17,6 +17,14 @@
 
 package java8_time
 
+import (
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
 type ZonedDateTime struct {
 	DateTime LocalDateTime `hessian:"dateTime"`
 	Offset   ZoneOffSet    `hessian:"offset"`
@@ -30,3 +38,26 @@ func (ZonedDateTime) JavaClassName() string {
 func (ZonedDateTime) Error() string {
 	return "encode ZonedDateTime error"
 }
+
+// ZonedDateTimeOf returns the date time, the offset and the zone of the time,
+// the offset id is used as the zone id if the location is the local one, which is unknown to java.
+func ZonedDateTimeOf(t time.Time) ZonedDateTime {
+	return ZonedDateTime{DateTime: LocalDateTimeOf(t), Offset: ZoneOffsetOf(t), ZoneId: ZoneIDOf(t)}
+}
+
+// Time returns the time in the location of the zone id, which is resolved by the tz database.
+// The instant is decided by the offset like java, so the time in an overlap of the zone is resolved correctly.
+func (dt ZonedDateTime) Time() (time.Time, error) {
+	if err := dt.DateTime.Validate(); err != nil {
+		return time.Time{}, err
+	}
+	if err := dt.Offset.Validate(); err != nil {
+		return time.Time{}, err
+	}
+	t := dt.DateTime.In(dt.Offset.Location())
+	loc, err := ZoneId{ZoneId: dt.ZoneId}.Location()
+	if err != nil {
+		return time.Time{}, perrors.Wrapf(err, "unknown zone id %s", dt.ZoneId)
+	}
+	return t.In(loc), nil
+}
@@ -0,0 +1,383 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"github.com/apache/dubbo-go-hessian2/java8_time"
+)
+
+// Java8TimeFormat is the wire format of the java.time types.
+type Java8TimeFormat int
+
+const (
+	// Java8TimeHessianLite writes the handle objects of the hessian-lite of dubbo, like com.alibaba.com.caucho.hessian.io.java8.InstantHandle,
+	// it's the default one.
+	Java8TimeHessianLite Java8TimeFormat = iota
+	// Java8TimeDubbo3 writes the handle objects of the hessian2 serialization of apache dubbo 3.
+	Java8TimeDubbo3
+	// Java8TimeCaucho writes the java.time objects with their own fields, which caucho hessian reads field by field.
+	// Caucho hessian writes them as java.time.Ser by writeReplace by default, which keeps no value and isn't supported,
+	// so the java side needs a serializer of the fields like UnsafeSerializer for the java.time classes.
+	Java8TimeCaucho
+)
+
+// the package of the handle classes of the formats.
+const (
+	java8TimeHessianLitePackage = "com.alibaba.com.caucho.hessian.io.java8."
+	java8TimeDubbo3Package      = "org.apache.dubbo.common.serialize.hessian2.java8."
+)
+
+// java8TimeClass describes the classes of a java.time type in all the formats.
+type java8TimeClass struct {
+	// handle is the simple name of the handle class of hessian-lite and dubbo 3
+	handle string
+	// caucho is the java.time class name, it's empty if the java class is not fixed
+	caucho string
+	// fields are the field names in the hessian tag of the go type and the java.time class, in the order of the java.time class.
+	fields [][2]string
+}
+
+var java8TimeClasses = map[reflect.Type]*java8TimeClass{
+	reflect.TypeOf(java8_time.Instant{}): {
+		handle: "InstantHandle", caucho: "java.time.Instant",
+		fields: [][2]string{{"seconds", "seconds"}, {"nanos", "nanos"}},
+	},
+	reflect.TypeOf(java8_time.Duration{}): {
+		handle: "DurationHandle", caucho: "java.time.Duration",
+		fields: [][2]string{{"seconds", "seconds"}, {"nanos", "nanos"}},
+	},
+	reflect.TypeOf(java8_time.LocalDate{}): {
+		handle: "LocalDateHandle", caucho: "java.time.LocalDate",
+		fields: [][2]string{{"year", "year"}, {"month", "month"}, {"day", "day"}},
+	},
+	reflect.TypeOf(java8_time.LocalTime{}): {
+		handle: "LocalTimeHandle", caucho: "java.time.LocalTime",
+		fields: [][2]string{{"hour", "hour"}, {"minute", "minute"}, {"second", "second"}, {"nano", "nano"}},
+	},
+	reflect.TypeOf(java8_time.LocalDateTime{}): {
+		handle: "LocalDateTimeHandle", caucho: "java.time.LocalDateTime",
+		fields: [][2]string{{"date", "date"}, {"time", "time"}},
+	},
+	reflect.TypeOf(java8_time.OffsetDateTime{}): {
+		handle: "OffsetDateTimeHandle", caucho: "java.time.OffsetDateTime",
+		fields: [][2]string{{"dateTime", "dateTime"}, {"offset", "offset"}},
+	},
+	reflect.TypeOf(java8_time.OffsetTime{}): {
+		handle: "OffsetTimeHandle", caucho: "java.time.OffsetTime",
+		fields: [][2]string{{"localTime", "time"}, {"zoneOffset", "offset"}},
+	},
+	reflect.TypeOf(java8_time.ZonedDateTime{}): {
+		handle: "ZonedDateTimeHandle", caucho: "java.time.ZonedDateTime",
+		fields: [][2]string{{"dateTime", "dateTime"}, {"offset", "offset"}, {"zoneId", "zone"}},
+	},
+	reflect.TypeOf(java8_time.ZoneOffSet{}): {
+		handle: "ZoneOffsetHandle", caucho: "java.time.ZoneOffset",
+		fields: [][2]string{{"seconds", "totalSeconds"}},
+	},
+	// ZoneId is a ZoneRegion or a ZoneOffset in java
+	reflect.TypeOf(java8_time.ZoneId{}): {
+		handle: "ZoneIdHandle",
+		fields: [][2]string{{"zoneId", "zoneId"}},
+	},
+	reflect.TypeOf(java8_time.Period{}): {
+		handle: "PeriodHandle", caucho: "java.time.Period",
+		fields: [][2]string{{"years", "years"}, {"months", "months"}, {"days", "days"}},
+	},
+	reflect.TypeOf(java8_time.Year{}): {
+		handle: "YearHandle", caucho: "java.time.Year",
+		fields: [][2]string{{"year", "year"}},
+	},
+	reflect.TypeOf(java8_time.YearMonth{}): {
+		handle: "YearMonthHandle", caucho: "java.time.YearMonth",
+		fields: [][2]string{{"year", "year"}, {"month", "month"}},
+	},
+	reflect.TypeOf(java8_time.MonthDay{}): {
+		handle: "MonthDayHandle", caucho: "java.time.MonthDay",
+		fields: [][2]string{{"month", "month"}, {"day", "day"}},
+	},
+}
+
+// className returns the class name of the format.
+func (c *java8TimeClass) className(format Java8TimeFormat) string {
+	switch format {
+	case Java8TimeDubbo3:
+		return java8TimeDubbo3Package + c.handle
+	case Java8TimeCaucho:
+		return c.caucho
+	default:
+		return java8TimeHessianLitePackage + c.handle
+	}
+}
+
+// format returns the format of the class name.
+func (c *java8TimeClass) format(className string) Java8TimeFormat {
+	switch {
+	case c.caucho != "" && className == c.caucho:
+		return Java8TimeCaucho
+	case strings.HasPrefix(className, java8TimeDubbo3Package):
+		return Java8TimeDubbo3
+	default:
+		return Java8TimeHessianLite
+	}
+}
+
+// wireField returns the field name of the format, the handles have the same field names as the go type.
+func (c *java8TimeClass) wireField(format Java8TimeFormat, i int) string {
+	if format == Java8TimeCaucho {
+		return c.fields[i][1]
+	}
+	return c.fields[i][0]
+}
+
+// goField returns the field name in the go type of the field name of the format.
+func (c *java8TimeClass) goField(format Java8TimeFormat, wireField string) (string, bool) {
+	for i := range c.fields {
+		if c.wireField(format, i) == wireField {
+			return c.fields[i][0], true
+		}
+	}
+	return "", false
+}
+
+// Java8TimeSerializer encodes the java8_time types in the format of Encoder.Java8TimeFormat, and decodes all the formats.
+type Java8TimeSerializer struct{}
+
+func (Java8TimeSerializer) EncObject(e *Encoder, v POJO) error {
+	vv := reflect.ValueOf(v)
+	// check ref
+	if n, ok := e.checkRefMap(vv); ok {
+		e.buffer = encRef(e.buffer, n)
+		return nil
+	}
+
+	vv = UnpackPtrValue(vv)
+	c, ok := java8TimeClasses[vv.Type()]
+	if !ok {
+		return perrors.Errorf("unexpected java8 time type %T", v)
+	}
+
+	format := e.Java8TimeFormat
+	if format == Java8TimeCaucho && c.caucho == "" {
+		return e.Encode(cauchoZone(vv.Interface().(java8_time.ZoneId).ZoneId))
+	}
+
+	fields := make([]string, len(c.fields))
+	for i := range c.fields {
+		fields[i] = c.wireField(format, i)
+	}
+	// the handles have the fields in the order of the go type
+	if format != Java8TimeCaucho {
+		fields = java8TimeGoFields(vv.Type())
+	}
+
+	idx := e.defineClass(&ClassInfo{javaName: c.className(format), fieldNameList: fields})
+	e.encObjectIndex(idx)
+	for _, field := range fields {
+		name, _ := c.goField(format, field)
+		fv := java8TimeFieldByTag(vv, name)
+		if !fv.IsValid() {
+			return perrors.Errorf("can not find field %s of %s", name, vv.Type())
+		}
+
+		var err error
+		if format == Java8TimeCaucho && fv.Kind() == reflect.String {
+			// the zone of ZonedDateTime is a ZoneId object
+			err = e.Encode(cauchoZone(fv.String()))
+		} else {
+			err = e.Encode(fv.Interface())
+		}
+		if err != nil {
+			return perrors.Wrapf(err, "failed to encode field %s of %s", field, vv.Type())
+		}
+	}
+	return nil
+}
+
+func (Java8TimeSerializer) DecObject(d *Decoder, typ reflect.Type, cls *ClassInfo) (interface{}, error) {
+	c, ok := java8TimeClasses[typ]
+	if !ok {
+		return nil, perrors.Errorf("unexpected java8 time type %s", typ)
+	}
+
+	vRef := reflect.New(typ)
+	// add pointer ref so that ref the same object
+	d.appendRefs(vRef.Interface())
+
+	format := c.format(cls.javaName)
+	for _, field := range cls.fieldNameList {
+		value, err := d.DecodeValue()
+		if err != nil {
+			return nil, perrors.Wrapf(err, "failed to decode field %s of %s", field, cls.javaName)
+		}
+		name, ok := c.goField(format, field)
+		if !ok {
+			continue
+		}
+		fv := java8TimeFieldByTag(vRef.Elem(), name)
+		if !fv.IsValid() {
+			continue
+		}
+		if err = setJava8TimeField(fv, value); err != nil {
+			return nil, perrors.Wrapf(err, "failed to set field %s of %s", field, cls.javaName)
+		}
+	}
+	return vRef.Interface(), nil
+}
+
+// java8TimeGoFields returns the field names in the hessian tag of the go type.
+func java8TimeGoFields(typ reflect.Type) []string {
+	fields := make([]string, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		fields = append(fields, parseFieldTag(typ.Field(i)).name)
+	}
+	return fields
+}
+
+func java8TimeFieldByTag(v reflect.Value, name string) reflect.Value {
+	for i := 0; i < v.NumField(); i++ {
+		if parseFieldTag(v.Type().Field(i)).name == name {
+			return v.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+// setJava8TimeField sets the decoded value to the field, the caucho java.time classes have fields of other int types and the zone objects.
+func setJava8TimeField(field reflect.Value, value interface{}) error {
+	value = EnsureRawAny(value)
+	if value == nil {
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.Int32, reflect.Int64:
+		switch i := value.(type) {
+		case int32:
+			field.SetInt(int64(i))
+		case int64:
+			field.SetInt(i)
+		default:
+			return perrors.Errorf("expect integer, but get %T", value)
+		}
+	case reflect.String:
+		switch z := value.(type) {
+		case string:
+			field.SetString(z)
+		case *java8_time.ZoneRegion:
+			field.SetString(z.ID)
+		case *java8_time.ZoneId:
+			field.SetString(z.ZoneId)
+		case *java8_time.ZoneOffSet:
+			field.SetString(z.ID())
+		default:
+			return perrors.Errorf("expect zone id, but get %T", value)
+		}
+	default:
+		SetValue(field, EnsurePackValue(value))
+	}
+	return nil
+}
+
+var java8ZoneIdType = reflect.TypeOf(java8_time.ZoneId{})
+
+// toJava8ZoneId converts the ZoneRegion and the ZoneOffset of caucho hessian to ZoneId.
+func toJava8ZoneId(v interface{}) interface{} {
+	switch z := v.(type) {
+	case *java8_time.ZoneRegion:
+		return &java8_time.ZoneId{ZoneId: z.ID}
+	case *java8_time.ZoneOffSet:
+		return &java8_time.ZoneId{ZoneId: z.ID()}
+	}
+	return v
+}
+
+// cauchoZone returns the java ZoneId object of the zone id, which is a ZoneOffset or a ZoneRegion.
+func cauchoZone(zoneID string) POJO {
+	offset, err := java8_time.ParseZoneOffset(zoneID)
+	if err == nil {
+		return &offset
+	}
+	return &java8_time.ZoneRegion{ID: zoneID}
+}
+
+// Java8EnumSerializer encodes and decodes the java.time enums, which are the objects with the enum name.
+type Java8EnumSerializer struct{}
+
+func (Java8EnumSerializer) EncObject(e *Encoder, v POJO) error {
+	name, ok := v.(fmt.Stringer)
+	if !ok {
+		return perrors.Errorf("unexpected java8 enum type %T", v)
+	}
+	e.encEnumName(v.JavaClassName(), name.String())
+	return nil
+}
+
+func (Java8EnumSerializer) DecObject(d *Decoder, typ reflect.Type, cls *ClassInfo) (interface{}, error) {
+	var (
+		name string
+		err  error
+	)
+	for _, field := range cls.fieldNameList {
+		value, decErr := d.DecodeValue()
+		if decErr != nil {
+			return nil, perrors.Wrapf(decErr, "failed to decode field %s of %s", field, cls.javaName)
+		}
+		if field == "name" {
+			name, _ = value.(string)
+		}
+	}
+
+	var v interface{}
+	switch cls.javaName {
+	case java8_time.DayOfWeek(0).JavaClassName():
+		v, err = java8_time.ParseDayOfWeek(name)
+	case java8_time.Month(0).JavaClassName():
+		v, err = java8_time.ParseMonth(name)
+	default:
+		err = perrors.Errorf("unexpected java8 enum %s", cls.javaName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	d.appendRefs(v)
+	return v, nil
+}
+
+// encEnumName encodes a java enum object of the class with the name,
+// the empty name is written as null, since java fails to find the enum constant of it.
+func (e *Encoder) encEnumName(javaName, name string) {
+	if name == "" {
+		e.buffer = EncNull(e.buffer)
+		return
+	}
+	// the java enums are refs too
+	e.checkRefMap(reflect.ValueOf(&name))
+	idx := e.defineClass(&ClassInfo{javaName: javaName, fieldNameList: []string{"name"}})
+	e.encObjectIndex(idx)
+	e.buffer = encString(e.buffer, name)
+}
@@ -0,0 +1,161 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+import (
+	"github.com/apache/dubbo-go-hessian2/java8_time"
+)
+
+type java8TimeHolder struct {
+	Instant        *java8_time.Instant        `hessian:"instant"`
+	Duration       java8_time.Duration        `hessian:"duration"`
+	LocalDateTime  *java8_time.LocalDateTime  `hessian:"localDateTime"`
+	OffsetTime     *java8_time.OffsetTime     `hessian:"offsetTime"`
+	ZonedDateTime  *java8_time.ZonedDateTime  `hessian:"zonedDateTime"`
+	OffsetZoned    *java8_time.ZonedDateTime  `hessian:"offsetZoned"`
+	OffsetDateTime *java8_time.OffsetDateTime `hessian:"offsetDateTime"`
+	Period         *java8_time.Period         `hessian:"period"`
+	YearMonth      *java8_time.YearMonth      `hessian:"yearMonth"`
+	MonthDay       *java8_time.MonthDay       `hessian:"monthDay"`
+	Year           *java8_time.Year           `hessian:"year"`
+	ZoneId         *java8_time.ZoneId         `hessian:"zoneId"`
+	Region         *java8_time.ZoneRegion     `hessian:"region"`
+	DayOfWeek      java8_time.DayOfWeek       `hessian:"dayOfWeek"`
+	Month          java8_time.Month           `hessian:"month"`
+}
+
+func (java8TimeHolder) JavaClassName() string {
+	return "test.model.Java8TimeHolder"
+}
+
+func newJava8TimeHolder(t *testing.T) *java8TimeHolder {
+	loc, err := time.LoadLocation("Europe/Paris")
+	assert.Nil(t, err)
+	now := time.Date(2021, 6, 16, 8, 30, 15, 123456789, loc)
+
+	instant := java8_time.InstantFromTime(time.Unix(-1, 5))
+	localDateTime := java8_time.LocalDateTimeOf(now)
+	zoned := java8_time.ZonedDateTimeOf(now)
+	offsetZoned := java8_time.ZonedDateTimeOf(now.In(time.FixedZone("", 3600)))
+	offsetDateTime := java8_time.OffsetDateTimeOf(now)
+	return &java8TimeHolder{
+		Instant:       &instant,
+		Duration:      java8_time.DurationFromStd(-time.Nanosecond),
+		LocalDateTime: &localDateTime,
+		OffsetTime: &java8_time.OffsetTime{
+			LocalTime:  java8_time.LocalTimeOf(now),
+			ZoneOffset: java8_time.ZoneOffsetOf(now),
+		},
+		ZonedDateTime:  &zoned,
+		OffsetZoned:    &offsetZoned,
+		OffsetDateTime: &offsetDateTime,
+		Period:         &java8_time.Period{Years: 1, Months: -2, Days: 3},
+		YearMonth:      &java8_time.YearMonth{Year: 2021, Month: 6},
+		MonthDay:       &java8_time.MonthDay{Month: 2, Day: 29},
+		Year:           &java8_time.Year{Year: -5},
+		ZoneId:         java8_time.NewZoneId(loc),
+		Region:         &java8_time.ZoneRegion{ID: "Asia/Tokyo"},
+		DayOfWeek:      java8_time.DayOfWeekOf(now.Weekday()),
+		Month:          java8_time.Month(now.Month()),
+	}
+}
+
+func TestJava8TimeFormat(t *testing.T) {
+	RegisterPOJO(&java8TimeHolder{})
+	holder := newJava8TimeHolder(t)
+	assert.Equal(t, java8_time.Wednesday, holder.DayOfWeek)
+	assert.Equal(t, time.Wednesday, holder.DayOfWeek.ToStd())
+	assert.Equal(t, "JUNE", holder.Month.String())
+
+	for _, format := range []Java8TimeFormat{Java8TimeHessianLite, Java8TimeDubbo3, Java8TimeCaucho} {
+		e := NewEncoder()
+		e.Java8TimeFormat = format
+		assert.Nil(t, e.Encode(holder))
+
+		res, err := NewDecoder(e.Buffer()).Decode()
+		assert.Nil(t, err)
+		assert.Equal(t, holder, res, "format %d", format)
+	}
+}
+
+func TestJava8TimeFormatLayout(t *testing.T) {
+	RegisterPOJO(&java8TimeHolder{})
+	holder := newJava8TimeHolder(t)
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(holder))
+	nodes, err := ParseValueTree(e.Buffer())
+	assert.Nil(t, err)
+	fields := nodes[0].Children
+	assert.Equal(t, "com.alibaba.com.caucho.hessian.io.java8.InstantHandle", fields[0].Type)
+	assert.Equal(t, "com.alibaba.com.caucho.hessian.io.java8.ZoneIdHandle", fields[11].Type)
+	assert.Equal(t, "java.time.DayOfWeek", fields[13].Type)
+	assert.Equal(t, "WEDNESDAY", fields[13].Children[0].Value)
+
+	e = NewEncoder()
+	e.Java8TimeFormat = Java8TimeDubbo3
+	assert.Nil(t, e.Encode(holder))
+	nodes, err = ParseValueTree(e.Buffer())
+	assert.Nil(t, err)
+	assert.Equal(t, "org.apache.dubbo.common.serialize.hessian2.java8.InstantHandle", nodes[0].Children[0].Type)
+
+	e = NewEncoder()
+	e.Java8TimeFormat = Java8TimeCaucho
+	assert.Nil(t, e.Encode(holder))
+	nodes, err = ParseValueTree(e.Buffer())
+	assert.Nil(t, err)
+	fields = nodes[0].Children
+	assert.Equal(t, "java.time.Instant", fields[0].Type)
+	offsetTime := fields[3]
+	assert.Equal(t, []string{"time", "offset"}, offsetTime.Fields)
+	assert.Equal(t, "java.time.ZoneOffset", offsetTime.Children[1].Type)
+	assert.Equal(t, []string{"totalSeconds"}, offsetTime.Children[1].Fields)
+	zoned := fields[4]
+	assert.Equal(t, []string{"dateTime", "offset", "zone"}, zoned.Fields)
+	assert.Equal(t, "java.time.ZoneRegion", zoned.Children[2].Type)
+	assert.Equal(t, "java.time.ZoneOffset", fields[5].Children[2].Type)
+	assert.Equal(t, []string{"years", "months", "days"}, fields[7].Fields)
+	assert.Equal(t, []string{"year", "month"}, fields[8].Fields)
+	assert.Equal(t, "java.time.ZoneRegion", fields[11].Type)
+}
+
+func TestJava8TimeFormatFromJava(t *testing.T) {
+	date := java8_time.LocalDate{Year: 2020, Month: 6, Day: 16}
+	dateTime := java8_time.LocalDateTime{Date: date, Time: java8_time.LocalTime{Hour: 6, Minute: 5, Second: 4, Nano: 3}}
+
+	// the handles of dubbo 3
+	testDecodeFramework(t, "java8_Dubbo3Instant", &java8_time.Instant{Seconds: 100, Nanos: 10})
+	testDecodeFramework(t, "java8_Dubbo3LocalDate", &date)
+
+	// the java.time objects written by caucho hessian
+	testDecodeFramework(t, "caucho_java8_Instant", &java8_time.Instant{Seconds: 100, Nanos: 10})
+	testDecodeFramework(t, "caucho_java8_Duration", &java8_time.Duration{Seconds: 30, Nanos: 10})
+	testDecodeFramework(t, "caucho_java8_LocalDateTime", &dateTime)
+	testDecodeFramework(t, "caucho_java8_Period", &java8_time.Period{Years: 2020, Months: 6, Days: 16})
+	testDecodeFramework(t, "caucho_java8_OffsetTime", &java8_time.OffsetTime{LocalTime: dateTime.Time, ZoneOffset: java8_time.ZoneOffSet{Seconds: 7200}})
+	testDecodeFramework(t, "caucho_java8_ZonedDateTime", &java8_time.ZonedDateTime{DateTime: dateTime, Offset: java8_time.ZoneOffSet{}, ZoneId: "Z"})
+}
@@ -18,7 +18,13 @@
 package hessian
 
 import (
+	"math"
 	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
 )
 
 import (
@@ -44,3 +50,126 @@ func TestJava8Time(t *testing.T) {
 func doTestTime(t *testing.T, method string, expected interface{}) {
 	testDecodeFramework(t, method, expected)
 }
+
+func TestJava8ZoneId(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Paris")
+	assert.Nil(t, err)
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(java8_time.NewZoneId(loc)))
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	zoneLoc, err := res.(*java8_time.ZoneId).Location()
+	assert.Nil(t, err)
+	assert.Equal(t, loc, zoneLoc)
+
+	// the local location is resolved to a zone id known to java
+	now := time.Now()
+	local := java8_time.NewZoneId(time.Local)
+	assert.NotEqual(t, "Local", local.ZoneId)
+	zoneLoc, err = local.Location()
+	assert.Nil(t, err)
+	_, offset := now.Zone()
+	_, zoneOffset := now.In(zoneLoc).Zone()
+	assert.Equal(t, offset, zoneOffset)
+	assert.Equal(t, local.ZoneId, java8_time.ZonedDateTimeOf(now).ZoneId)
+
+	assert.Equal(t, "+01:00", java8_time.NewZoneId(time.FixedZone("", 3600)).ZoneId)
+}
+
+func TestJava8TimeInstant(t *testing.T) {
+	for _, tm := range []time.Time{
+		time.Unix(1623832215, 123456789).UTC(),
+		time.Unix(-1, 1).UTC(),
+		time.Date(1900, 1, 1, 0, 0, 0, 999999999, time.UTC),
+	} {
+		instant := java8_time.InstantFromTime(tm)
+		assert.Nil(t, instant.Validate())
+		assert.True(t, instant.Nanos >= 0)
+		assert.Equal(t, tm, instant.Time())
+
+		e := NewEncoder()
+		assert.Nil(t, e.Encode(&instant))
+		res, err := NewDecoder(e.Buffer()).Decode()
+		assert.Nil(t, err)
+		assert.Equal(t, tm, res.(*java8_time.Instant).Time())
+	}
+	assert.Equal(t, java8_time.Instant{Seconds: -2, Nanos: 999999999}, java8_time.InstantFromTime(time.Unix(-1, -1)))
+	assert.NotNil(t, java8_time.Instant{Nanos: -1}.Validate())
+}
+
+func TestJava8TimeDuration(t *testing.T) {
+	for _, d := range []time.Duration{0, 1500 * time.Millisecond, -1, -1500 * time.Millisecond, math.MaxInt64, math.MinInt64} {
+		jd := java8_time.DurationFromStd(d)
+		assert.True(t, jd.Nanos >= 0)
+		std, err := jd.ToStd()
+		assert.Nil(t, err)
+		assert.Equal(t, d, std)
+	}
+	assert.Equal(t, java8_time.Duration{Seconds: -2, Nanos: 5e8}, java8_time.DurationFromStd(-1500*time.Millisecond))
+
+	_, err := java8_time.Duration{Seconds: 9223372036, Nanos: 854775808}.ToStd()
+	assert.NotNil(t, err)
+	_, err = java8_time.Duration{Seconds: -9223372037, Nanos: 145224191}.ToStd()
+	assert.NotNil(t, err)
+	_, err = java8_time.Duration{Seconds: 1, Nanos: 1e9}.ToStd()
+	assert.NotNil(t, err)
+}
+
+func TestJava8TimeLocalDateTime(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+	tm := time.Date(2021, 3, 14, 1, 59, 59, 999999999, loc)
+
+	dt := java8_time.LocalDateTimeOf(tm)
+	assert.Nil(t, dt.Validate())
+	assert.Equal(t, java8_time.LocalTime{Hour: 1, Minute: 59, Second: 59, Nano: 999999999}, dt.Time)
+	assert.Equal(t, tm, dt.In(loc))
+	assert.Equal(t, time.Date(2021, 3, 14, 0, 0, 0, 0, loc), dt.Date.In(loc))
+	assert.Equal(t, time.Hour+59*time.Minute+59*time.Second+999999999, dt.Time.SinceMidnight())
+
+	_, err = java8_time.NewLocalDate(2021, 2, 29)
+	assert.NotNil(t, err)
+	_, err = java8_time.NewLocalDate(2020, 2, 29)
+	assert.Nil(t, err)
+	_, err = java8_time.NewLocalTime(24, 0, 0, 0)
+	assert.NotNil(t, err)
+	_, err = java8_time.NewLocalTime(23, 59, 59, -1)
+	assert.NotNil(t, err)
+}
+
+func TestJava8TimeZonedDateTime(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+	// 01:30 happens twice when the daylight saving ends, the offset decides the instant
+	first := time.Date(2021, 11, 7, 5, 30, 0, 1, time.UTC).In(loc)
+	second := first.Add(time.Hour)
+	for _, tm := range []time.Time{first, second} {
+		zdt := java8_time.ZonedDateTimeOf(tm)
+		assert.Equal(t, "America/New_York", zdt.ZoneId)
+
+		e := NewEncoder()
+		assert.Nil(t, e.Encode(&zdt))
+		res, err := NewDecoder(e.Buffer()).Decode()
+		assert.Nil(t, err)
+		got, err := res.(*java8_time.ZonedDateTime).Time()
+		assert.Nil(t, err)
+		assert.True(t, tm.Equal(got))
+		assert.Equal(t, loc, got.Location())
+	}
+
+	zdt := java8_time.ZonedDateTimeOf(time.Date(1960, 1, 1, 0, 0, 0, 0, time.FixedZone("", -(3*3600+1800))))
+	assert.Equal(t, "-03:30", zdt.ZoneId)
+	got, err := zdt.Time()
+	assert.Nil(t, err)
+	_, offset := got.Zone()
+	assert.Equal(t, -(3*3600 + 1800), offset)
+
+	zdt.ZoneId = "Mars/Olympus"
+	_, err = zdt.Time()
+	assert.NotNil(t, err)
+
+	odt := java8_time.OffsetDateTimeOf(first)
+	assert.Equal(t, "-04:00", odt.Offset.ID())
+	assert.True(t, first.Equal(odt.Time()))
+}
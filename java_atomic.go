@@ -0,0 +1,138 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"reflect"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"github.com/apache/dubbo-go-hessian2/java_util"
+)
+
+func init() {
+	for _, v := range []POJO{
+		&java_util.AtomicInteger{},
+		&java_util.AtomicLong{},
+		&java_util.AtomicBoolean{},
+		&java_util.AtomicReference{},
+	} {
+		RegisterPOJO(v)
+		SetSerializer(v.JavaClassName(), AtomicSerializer{})
+	}
+}
+
+// the only field of the java atomic classes
+const atomicValueField = "value"
+
+// AtomicSerializer encodes the java.util.concurrent.atomic classes as objects with a value field, like the java impl.
+type AtomicSerializer struct{}
+
+func (AtomicSerializer) EncObject(e *Encoder, v POJO) error {
+	// check ref
+	if n, ok := e.checkRefMap(reflect.ValueOf(v)); ok {
+		e.buffer = encRef(e.buffer, n)
+		return nil
+	}
+
+	var value interface{}
+	switch a := v.(type) {
+	case *java_util.AtomicInteger:
+		value = a.Get()
+	case *java_util.AtomicLong:
+		value = a.Get()
+	case *java_util.AtomicBoolean:
+		// the value of java AtomicBoolean is an int
+		value = int32(0)
+		if a.Get() {
+			value = int32(1)
+		}
+	case *java_util.AtomicReference:
+		value = a.Get()
+	default:
+		return perrors.Errorf("unexpected atomic type %T", v)
+	}
+
+	idx := e.defineClass(&ClassInfo{javaName: v.JavaClassName(), fieldNameList: []string{atomicValueField}})
+	e.encObjectIndex(idx)
+	return e.Encode(value)
+}
+
+func (AtomicSerializer) DecObject(d *Decoder, typ reflect.Type, cls *ClassInfo) (interface{}, error) {
+	vRef := reflect.New(typ)
+	// add pointer ref so that ref the same object
+	d.appendRefs(vRef.Interface())
+
+	for _, fieldName := range cls.fieldNameList {
+		value, err := d.Decode()
+		if err != nil {
+			return nil, perrors.Wrapf(err, "decode atomic field %s", fieldName)
+		}
+		if fieldName != atomicValueField {
+			continue
+		}
+
+		switch a := vRef.Interface().(type) {
+		case *java_util.AtomicInteger:
+			i, err := atomicInt64(value)
+			if err != nil {
+				return nil, err
+			}
+			a.Set(int32(i))
+		case *java_util.AtomicLong:
+			i, err := atomicInt64(value)
+			if err != nil {
+				return nil, err
+			}
+			a.Set(i)
+		case *java_util.AtomicBoolean:
+			if b, ok := value.(bool); ok {
+				a.Set(b)
+				break
+			}
+			i, err := atomicInt64(value)
+			if err != nil {
+				return nil, err
+			}
+			a.Set(i != 0)
+		case *java_util.AtomicReference:
+			a.Set(value)
+		default:
+			return nil, perrors.Errorf("unexpected atomic type %s", typ)
+		}
+	}
+
+	return vRef.Interface(), nil
+}
+
+func atomicInt64(v interface{}) (int64, error) {
+	switch i := v.(type) {
+	case nil:
+		return 0, nil
+	case int32:
+		return int64(i), nil
+	case int64:
+		return i, nil
+	default:
+		return 0, perrors.Errorf("expect integer atomic value, but get %T", v)
+	}
+}
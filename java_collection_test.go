@@ -21,6 +21,14 @@ import (
 	"testing"
 )
 
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+import (
+	"github.com/apache/dubbo-go-hessian2/java_util"
+)
+
 func init() {
 	SetCollectionSerialize(&JavaHashSet{})
 }
@@ -56,3 +64,91 @@ func TestListJavaCollectionDecode(t *testing.T) {
 	hashSet := JavaHashSet{value: inside}
 	testDecodeFramework(t, "customReplyTypedFixedList_HashSet", &hashSet)
 }
+
+type setHolder struct {
+	Ids []int32
+}
+
+func (setHolder) JavaClassName() string {
+	return "test.model.SetHolder"
+}
+
+func TestJavaSet(t *testing.T) {
+	// the java sets are decoded as slices by default
+	e := NewEncoder()
+	assert.Nil(t, writeCollectionBegin(2, "java.util.LinkedHashSet", e))
+	assert.Nil(t, e.Encode(int32(1)))
+	assert.Nil(t, e.Encode(int32(2)))
+	wire := e.Buffer()
+	res, err := NewDecoder(wire).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{int32(1), int32(2)}, res)
+
+	RegisterJavaUtilSets()
+	defer func() {
+		delete(collectionTypeMap, "java.util.LinkedHashSet")
+		delete(collectionTypeMap, "java.util.TreeSet")
+		SetCollectionSerialize(&JavaHashSet{})
+	}()
+
+	res, err = NewDecoder(wire).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, java_util.NewLinkedHashSet(int32(1), int32(2)), res)
+
+	// the set is decoded into the slice field
+	RegisterPOJO(&setHolder{})
+	e = NewEncoder()
+	assert.Nil(t, e.EncodeMapAsObject(&ClassInfo{
+		javaName:      "test.model.SetHolder",
+		fieldNameList: []string{"ids"},
+	}, map[string]interface{}{"ids": java_util.NewHashSet(int32(1), int32(2))}))
+	res, err = NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, []int32{1, 2}, res.(*setHolder).Ids)
+
+	for _, set := range []JavaCollectionObject{
+		java_util.NewHashSet("b", "a", "b"),
+		java_util.NewLinkedHashSet("b", "a", "b"),
+		java_util.NewTreeSet("a", "b", "b"),
+	} {
+		assert.Equal(t, 2, len(set.Get()))
+
+		e := NewEncoder()
+		assert.Nil(t, e.Encode(set))
+		assert.Equal(t, BC_LIST_DIRECT+2, e.Buffer()[0])
+
+		res, err := NewDecoder(e.Buffer()).Decode()
+		assert.Nil(t, err)
+		assert.Equal(t, set, res)
+	}
+
+	// the duplicate values are removed when decoding
+	e = NewEncoder()
+	assert.Nil(t, writeCollectionBegin(3, "java.util.HashSet", e))
+	assert.Nil(t, e.Encode(int32(1)))
+	assert.Nil(t, e.Encode(int32(2)))
+	assert.Nil(t, e.Encode(int32(1)))
+	res, err = NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	set := res.(*java_util.HashSet)
+	assert.Equal(t, []interface{}{int32(1), int32(2)}, set.Values())
+	assert.True(t, set.Contains(int32(2)))
+	assert.False(t, set.Contains(int32(3)))
+
+	// the values which are not comparable
+	set = java_util.NewHashSet([]interface{}{"a"}, []interface{}{"a"})
+	assert.Equal(t, 1, set.Len())
+	assert.True(t, set.Remove([]interface{}{"a"}))
+	assert.Equal(t, 0, set.Len())
+
+	// the comparable types holding the values which are not comparable
+	type holder struct {
+		Value interface{}
+	}
+	set = java_util.NewHashSet(holder{[]int{1}}, holder{[]int{1}}, [1]interface{}{map[string]int{}}, holder{1})
+	assert.Equal(t, 3, set.Len())
+	assert.True(t, set.Contains(holder{[]int{1}}))
+	assert.True(t, set.Contains(holder{1}))
+	assert.True(t, set.Remove([1]interface{}{map[string]int{}}))
+	assert.Equal(t, 2, set.Len())
+}
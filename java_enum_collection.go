@@ -0,0 +1,366 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"reflect"
+	"sort"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"github.com/apache/dubbo-go-hessian2/java_exception"
+)
+
+const (
+	javaEnumSetClass = "java.util.EnumSet"
+	javaEnumMapClass = "java.util.EnumMap"
+
+	// java EnumSet is written as an EnumSetHandler object which has the enum type and the members.
+	enumSetHandlerClass       = "com.alibaba.com.caucho.hessian.io.EnumSetHandler"
+	cauchoEnumSetHandlerClass = "com.caucho.hessian.io.EnumSetHandler"
+	enumSetTypeField          = "type"
+	enumSetObjectsField       = "objects"
+)
+
+func init() {
+	RegisterPOJOMapping(cauchoEnumSetHandlerClass, &EnumSet{})
+	RegisterPOJOMapping(enumSetHandlerClass, &EnumSet{})
+	SetSerializer(javaEnumSetClass, EnumSetSerializer{})
+	SetSerializer(enumSetHandlerClass, EnumSetSerializer{})
+	SetSerializer(cauchoEnumSetHandlerClass, EnumSetSerializer{})
+
+	RegisterPOJO(&EnumMap{})
+	SetSerializer(javaEnumMapClass, EnumMapSerializer{})
+}
+
+var enumMapType = reflect.TypeOf(EnumMap{})
+
+// EnumSet is java.util.EnumSet, the values are the registered enums of the class EnumClass.
+type EnumSet struct {
+	EnumClass string
+	Values    []POJOEnum
+}
+
+// NewEnumSet creates an EnumSet of the enum class with the given values.
+func NewEnumSet(enumClass string, values ...POJOEnum) *EnumSet {
+	s := &EnumSet{EnumClass: enumClass}
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+func (EnumSet) JavaClassName() string {
+	return javaEnumSetClass
+}
+
+// Add adds the enum value, it returns false if the value exists.
+func (s *EnumSet) Add(v POJOEnum) bool {
+	if s.Contains(v) {
+		return false
+	}
+	if s.EnumClass == "" {
+		s.EnumClass = v.JavaClassName()
+	}
+	s.Values = append(s.Values, v)
+	return true
+}
+
+// Contains checks whether the enum value exists.
+func (s *EnumSet) Contains(v POJOEnum) bool {
+	for _, value := range s.Values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// EnumMap is java.util.EnumMap, the keys are the registered enums of the class EnumClass.
+type EnumMap struct {
+	EnumClass string
+	Values    map[POJOEnum]interface{}
+}
+
+// NewEnumMap creates an empty EnumMap of the enum class.
+func NewEnumMap(enumClass string) *EnumMap {
+	return &EnumMap{EnumClass: enumClass, Values: make(map[POJOEnum]interface{})}
+}
+
+func (EnumMap) JavaClassName() string {
+	return javaEnumMapClass
+}
+
+// Put sets the value of the enum key.
+func (m *EnumMap) Put(k POJOEnum, v interface{}) {
+	if m.Values == nil {
+		m.Values = make(map[POJOEnum]interface{})
+	}
+	if m.EnumClass == "" {
+		m.EnumClass = k.JavaClassName()
+	}
+	m.Values[k] = v
+}
+
+// Get returns the value of the enum key.
+func (m *EnumMap) Get(k POJOEnum) (interface{}, bool) {
+	v, ok := m.Values[k]
+	return v, ok
+}
+
+// Keys returns the enum keys in the order of their ordinal like java.
+func (m *EnumMap) Keys() []POJOEnum {
+	keys := make([]POJOEnum, 0, len(m.Values))
+	for k := range m.Values {
+		keys = append(keys, k)
+	}
+	sortEnums(keys)
+	return keys
+}
+
+// sortEnums sorts the enums by the ordinal, which is the int value of the generated enums.
+func sortEnums(values []POJOEnum) {
+	sort.SliceStable(values, func(i, j int) bool {
+		return enumOrdinal(values[i]) < enumOrdinal(values[j])
+	})
+}
+
+func enumOrdinal(v POJOEnum) int64 {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	default:
+		return 0
+	}
+}
+
+// toPOJOEnum converts the decoded JavaEnum to the registered enum type of the java class.
+func toPOJOEnum(javaName string, v JavaEnum) (POJOEnum, error) {
+	info, ok := getStructInfo(javaName)
+	if !ok || !info.typ.Implements(javaEnumType) {
+		return nil, perrors.Errorf("java enum %s has not being registered before", javaName)
+	}
+	if !reflect.TypeOf(v).ConvertibleTo(info.typ) {
+		return nil, perrors.Errorf("can not convert enum value to %s", info.typ)
+	}
+	return reflect.ValueOf(v).Convert(info.typ).Interface().(POJOEnum), nil
+}
+
+// EnumSetSerializer encodes EnumSet as the EnumSetHandler object of java hessian.
+type EnumSetSerializer struct{}
+
+func (EnumSetSerializer) EncObject(e *Encoder, v POJO) error {
+	s, ok := v.(*EnumSet)
+	if !ok {
+		return perrors.Errorf("unexpected enum set type %T", v)
+	}
+	// check ref
+	if n, ok := e.checkRefMap(reflect.ValueOf(s)); ok {
+		e.buffer = encRef(e.buffer, n)
+		return nil
+	}
+
+	enumClass := s.EnumClass
+	if enumClass == "" && len(s.Values) > 0 {
+		enumClass = s.Values[0].JavaClassName()
+	}
+	if enumClass == "" {
+		return perrors.New("the enum class of EnumSet is unknown")
+	}
+
+	values := make([]POJOEnum, len(s.Values))
+	copy(values, s.Values)
+	sortEnums(values)
+	objects := make([]interface{}, len(values))
+	for i := range values {
+		objects[i] = values[i]
+	}
+
+	idx := e.defineClass(&ClassInfo{
+		javaName:      enumSetHandlerClass,
+		fieldNameList: []string{enumSetTypeField, enumSetObjectsField},
+	})
+	e.encObjectIndex(idx)
+	if err := e.Encode(&java_exception.Class{Name: enumClass}); err != nil {
+		return perrors.WithStack(err)
+	}
+	return e.Encode(objects)
+}
+
+func (EnumSetSerializer) DecObject(d *Decoder, typ reflect.Type, cls *ClassInfo) (interface{}, error) {
+	s := &EnumSet{}
+	d.appendRefs(s)
+
+	var objects []interface{}
+	for _, fieldName := range cls.fieldNameList {
+		value, err := d.Decode()
+		if err != nil {
+			return nil, perrors.Wrapf(err, "decode EnumSet field %s", fieldName)
+		}
+		switch fieldName {
+		case enumSetTypeField:
+			s.EnumClass = javaClassName(value)
+		case enumSetObjectsField:
+			if value == nil {
+				continue
+			}
+			var ok bool
+			if objects, ok = value.([]interface{}); !ok {
+				return nil, perrors.Errorf("expect list of enums, but get %T", value)
+			}
+		}
+	}
+
+	for _, o := range objects {
+		enum, ok := o.(JavaEnum)
+		if !ok {
+			return nil, perrors.Errorf("expect enum value of %s, but get %T", s.EnumClass, o)
+		}
+		v, err := toPOJOEnum(s.EnumClass, enum)
+		if err != nil {
+			return nil, err
+		}
+		s.Add(v)
+	}
+
+	return s, nil
+}
+
+// javaClassName returns the name of the decoded java.lang.Class object.
+func javaClassName(v interface{}) string {
+	switch c := v.(type) {
+	case *java_exception.Class:
+		return c.Name
+	case java_exception.Class:
+		return c.Name
+	case map[string]interface{}:
+		name, _ := c["name"].(string)
+		return name
+	case string:
+		return c
+	}
+	return ""
+}
+
+// EnumMapSerializer encodes EnumMap as a typed map of which the keys are enum objects.
+type EnumMapSerializer struct{}
+
+func (EnumMapSerializer) EncObject(e *Encoder, v POJO) error {
+	m, ok := v.(*EnumMap)
+	if !ok {
+		return perrors.Errorf("unexpected enum map type %T", v)
+	}
+	// check ref
+	if n, ok := e.checkRefMap(reflect.ValueOf(m)); ok {
+		e.buffer = encRef(e.buffer, n)
+		return nil
+	}
+
+	e.buffer = encByte(e.buffer, BC_MAP)
+	e.buffer = encString(e.buffer, javaEnumMapClass)
+	for _, k := range m.Keys() {
+		if err := e.Encode(k); err != nil {
+			return perrors.WithStack(err)
+		}
+		if err := e.Encode(m.Values[k]); err != nil {
+			return perrors.WithStack(err)
+		}
+	}
+	e.buffer = encByte(e.buffer, BC_END)
+	return nil
+}
+
+func (EnumMapSerializer) DecObject(d *Decoder, typ reflect.Type, cls *ClassInfo) (interface{}, error) {
+	return nil, perrors.Errorf("EnumMap should be decoded as a map, but get an object of %s", cls.javaName)
+}
+
+// decodeEnumMap decodes the entries of the java EnumMap, the 'M' and the type have been read.
+func (d *Decoder) decodeEnumMap() (interface{}, error) {
+	m := &EnumMap{Values: make(map[POJOEnum]interface{})}
+	d.appendRefs(m)
+
+	for d.peekByte() != BC_END {
+		k, err := d.decEnumKey(m.EnumClass)
+		if err != nil {
+			return nil, perrors.Wrap(err, "decode EnumMap key")
+		}
+		v, err := d.Decode()
+		if err != nil {
+			return nil, perrors.Wrap(err, "decode EnumMap value")
+		}
+		m.Put(k, v)
+	}
+	if _, err := d.ReadByte(); err != nil {
+		return nil, perrors.WithStack(err)
+	}
+
+	return m, nil
+}
+
+// decEnumKey decodes an enum object and converts it to the registered enum type,
+// the enumClass is used when the enum is a ref.
+func (d *Decoder) decEnumKey(enumClass string) (POJOEnum, error) {
+	tag, err := d.ReadByte()
+	if err != nil {
+		return nil, perrors.WithStack(err)
+	}
+
+	var idx int32
+	switch {
+	case tag == BC_OBJECT_DEF:
+		clsDef, err := d.decClassDef()
+		if err != nil {
+			return nil, perrors.WithStack(err)
+		}
+		d.appendClsDef(clsDef.(*ClassInfo))
+		return d.decEnumKey(enumClass)
+	case tag == BC_REF:
+		ref, err := d.decRef(int32(tag))
+		if err != nil {
+			return nil, err
+		}
+		enum, ok := ref.(JavaEnum)
+		if !ok {
+			return nil, perrors.Errorf("expect ref to enum, but get %T", ref)
+		}
+		return toPOJOEnum(enumClass, enum)
+	case tag == BC_OBJECT:
+		if idx, err = d.decInt32(TAG_READ); err != nil {
+			return nil, err
+		}
+	case BC_OBJECT_DIRECT <= tag && tag <= (BC_OBJECT_DIRECT+OBJECT_DIRECT_MAX):
+		idx = int32(tag - BC_OBJECT_DIRECT)
+	default:
+		return nil, perrors.Errorf("expect enum object, but get tag %x", tag)
+	}
+
+	_, cls, err := d.getStructDefByIndex(int(idx))
+	if err != nil {
+		return nil, err
+	}
+	enum, err := d.decEnum(cls.javaName, TAG_READ)
+	if err != nil {
+		return nil, err
+	}
+	return toPOJOEnum(cls.javaName, enum)
+}
@@ -0,0 +1,134 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+type testColor JavaEnum
+
+const (
+	testColorRed testColor = iota
+	testColorGreen
+	testColorBlue
+)
+
+var testColorNames = []string{"RED", "GREEN", "BLUE"}
+
+func (testColor) JavaClassName() string {
+	return "test.model.Color"
+}
+
+func (c testColor) String() string {
+	return testColorNames[c]
+}
+
+func (testColor) EnumValue(s string) JavaEnum {
+	for i, name := range testColorNames {
+		if name == s {
+			return JavaEnum(i)
+		}
+	}
+	return InvalidJavaEnum
+}
+
+type enumCollectionHolder struct {
+	Colors    *EnumSet `hessian:"colors"`
+	Empty     *EnumSet `hessian:"empty"`
+	Favorites *EnumMap `hessian:"favorites"`
+}
+
+func (enumCollectionHolder) JavaClassName() string {
+	return "test.model.EnumCollectionHolder"
+}
+
+func init() {
+	for _, c := range []testColor{testColorRed, testColorGreen, testColorBlue} {
+		RegisterJavaEnum(c)
+	}
+	RegisterPOJO(&enumCollectionHolder{})
+}
+
+func TestEnumSet(t *testing.T) {
+	s := NewEnumSet("", testColorBlue, testColorRed, testColorBlue)
+	assert.Equal(t, "test.model.Color", s.EnumClass)
+	assert.Equal(t, 2, len(s.Values))
+	assert.True(t, s.Contains(testColorRed))
+	assert.False(t, s.Contains(testColorGreen))
+
+	favorites := NewEnumMap("test.model.Color")
+	favorites.Put(testColorGreen, "grass")
+	favorites.Put(testColorRed, int32(1))
+	assert.Equal(t, []POJOEnum{testColorRed, testColorGreen}, favorites.Keys())
+
+	holder := &enumCollectionHolder{
+		Colors:    s,
+		Empty:     NewEnumSet("test.model.Color"),
+		Favorites: favorites,
+	}
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(holder))
+
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	h := res.(*enumCollectionHolder)
+	// the values are sorted by the ordinal like java
+	assert.Equal(t, []POJOEnum{testColorRed, testColorBlue}, h.Colors.Values)
+	assert.Equal(t, "test.model.Color", h.Colors.EnumClass)
+	assert.Equal(t, "test.model.Color", h.Empty.EnumClass)
+	assert.Empty(t, h.Empty.Values)
+	assert.Equal(t, favorites, h.Favorites)
+	v, ok := h.Favorites.Get(testColorGreen)
+	assert.True(t, ok)
+	assert.Equal(t, "grass", v)
+
+	// the same layout as java hessian
+	nodes, err := ParseValueTree(e.Buffer())
+	assert.Nil(t, err)
+	colors := nodes[0].Children[0]
+	assert.Equal(t, enumSetHandlerClass, colors.Type)
+	assert.Equal(t, []string{"type", "objects"}, colors.Fields)
+	assert.Equal(t, "java.lang.Class", colors.Children[0].Type)
+	assert.Equal(t, "test.model.Color", colors.Children[0].Children[0].Value)
+	assert.Equal(t, KindList, colors.Children[1].Kind)
+	assert.Equal(t, "RED", colors.Children[1].Children[0].Children[0].Value)
+	assert.Equal(t, KindMap, nodes[0].Children[2].Kind)
+	assert.Equal(t, "java.util.EnumMap", nodes[0].Children[2].Type)
+}
+
+func TestEnumSetDecodeCaucho(t *testing.T) {
+	e := NewEncoder()
+	idx := e.defineClass(&ClassInfo{
+		javaName:      cauchoEnumSetHandlerClass,
+		fieldNameList: []string{enumSetTypeField, enumSetObjectsField},
+	})
+	e.encObjectIndex(idx)
+	// the EnumSetHandler of the caucho hessian
+	assert.Nil(t, e.EncodeMapAsObject(&ClassInfo{javaName: "java.lang.Class", fieldNameList: []string{"name"}},
+		map[string]interface{}{"name": "test.model.Color"}))
+	assert.Nil(t, e.Encode([]interface{}{testColorGreen, testColorGreen}))
+
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, NewEnumSet("test.model.Color", testColorGreen), res)
+}
@@ -0,0 +1,140 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"reflect"
+	"sync"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+// the only field of the java enum objects
+const javaEnumNameField = "name"
+
+// javaEnumNames stores the name set of the java enum classes registered by RegisterJavaEnumValues.
+var javaEnumNames = &sync.Map{}
+
+// RegisterJavaEnumValues registers the names of a java enum class without a go enum type,
+// its values are decoded into JavaEnumValue, and the unknown names are checked like the registered enums.
+func RegisterJavaEnumValues(javaName string, names []string) {
+	set := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		set[name] = struct{}{}
+	}
+	javaEnumNames.Store(javaName, set)
+}
+
+// isRegisteredJavaEnumValues checks whether the java enum class is registered by RegisterJavaEnumValues.
+func isRegisteredJavaEnumValues(javaName string) bool {
+	_, ok := javaEnumNames.Load(javaName)
+	return ok
+}
+
+// JavaEnumValue is the value of an unregistered java enum class, it's encoded as the same enum object.
+type JavaEnumValue struct {
+	Class string
+	Name  string
+}
+
+// JavaClassName returns the java class name of the enum.
+func (v JavaEnumValue) JavaClassName() string {
+	return v.Class
+}
+
+// String returns the name of the enum constant.
+func (v JavaEnumValue) String() string {
+	return v.Name
+}
+
+// isJavaEnumClass checks whether the class definition is the one of java enums, which has the only field "name".
+func isJavaEnumClass(cls *ClassInfo) bool {
+	return len(cls.fieldNameList) == 1 && cls.fieldNameList[0] == javaEnumNameField
+}
+
+// isJavaEnumValueClass checks whether the objects of the unregistered class are decoded into JavaEnumValue,
+// the ordinary classes with the only field "name" are decoded into maps unless Decoder.DetectJavaEnumValue is true.
+func (d *Decoder) isJavaEnumValueClass(cls *ClassInfo) bool {
+	return isJavaEnumClass(cls) && (d.DetectJavaEnumValue || isRegisteredJavaEnumValues(cls.javaName))
+}
+
+// decJavaEnumValue decodes the object of an unregistered enum class into JavaEnumValue,
+// it falls back to the map like decClassToMap if the name is not a string.
+func (d *Decoder) decJavaEnumValue(cls *ClassInfo) (interface{}, error) {
+	// hold the ref index before decoding the name
+	refIdx := len(d.refs)
+	d.appendRefs(nil)
+
+	name, err := d.DecodeValue()
+	if err != nil {
+		return nil, perrors.Wrapf(err, "decJavaEnumValue -> decode name of enum %s", cls.javaName)
+	}
+
+	var v interface{}
+	if s, ok := name.(string); ok {
+		if names, registered := javaEnumNames.Load(cls.javaName); registered && d.DisallowUnknownEnum {
+			if _, known := names.(map[string]struct{})[s]; !known {
+				return nil, perrors.Errorf("unknown name %s of enum %s", s, cls.javaName)
+			}
+		}
+		v = JavaEnumValue{Class: cls.javaName, Name: s}
+	} else {
+		v = map[string]interface{}{ClassKey: cls.javaName, javaEnumNameField: name}
+	}
+	d.refs[refIdx] = v
+	return v, nil
+}
+
+// isStringEnumType checks whether the type is a java enum of go string type, like
+//
+//	type Color string
+//
+//	func (Color) JavaClassName() string {
+//		return "com.test.Color"
+//	}
+func isStringEnumType(typ reflect.Type) bool {
+	return typ.Kind() == reflect.String && typ.Implements(pojoType)
+}
+
+// convertStringEnum converts the decoded enum or string to the go string type.
+func convertStringEnum(v interface{}, typ reflect.Type) (reflect.Value, bool) {
+	if typ.Kind() != reflect.String {
+		return _zeroValue, false
+	}
+	var name string
+	switch e := v.(type) {
+	case JavaEnumValue:
+		name = e.Name
+	case map[string]interface{}:
+		// the enum of an unregistered class decoded like decClassToMap
+		s, ok := e[javaEnumNameField].(string)
+		if !ok || len(e) != 2 {
+			return _zeroValue, false
+		}
+		name = s
+	case POJOEnum:
+		name = e.String()
+	case string:
+		name = e
+	default:
+		return _zeroValue, false
+	}
+	return reflect.ValueOf(name).Convert(typ), true
+}
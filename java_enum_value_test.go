@@ -0,0 +1,196 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJavaEnumValue(t *testing.T) {
+	shape := JavaEnumValue{Class: "test.model.UnregisteredShape", Name: "CIRCLE"}
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode([]interface{}{shape, &shape, JavaEnumValue{Class: shape.Class, Name: "SQUARE"}}))
+	d := NewDecoder(e.Buffer())
+	d.DetectJavaEnumValue = true
+	res, err := d.Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{shape, shape, JavaEnumValue{Class: shape.Class, Name: "SQUARE"}}, res)
+
+	// the same bytes as the registered enum
+	e = NewEncoder()
+	assert.Nil(t, e.Encode(testColorGreen))
+	registered := e.Buffer()
+	e = NewEncoder()
+	assert.Nil(t, e.Encode(JavaEnumValue{Class: "test.model.Color", Name: "GREEN"}))
+	assert.Equal(t, registered, e.Buffer())
+
+	// the name which is not a string is still decoded into map
+	e = NewEncoder()
+	idx := e.defineClass(&ClassInfo{javaName: "test.model.Named", fieldNameList: []string{"name"}})
+	e.encObjectIndex(idx)
+	e.buffer = encInt32(e.buffer, 1)
+	d = NewDecoder(e.Buffer())
+	d.DetectJavaEnumValue = true
+	res, err = d.Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{ClassKey: "test.model.Named", "name": int32(1)}, res)
+}
+
+type namedPOJO struct {
+	Name string `hessian:"name"`
+}
+
+func (namedPOJO) JavaClassName() string {
+	return "test.model.NamedPOJO"
+}
+
+func TestJavaEnumValueNotDetected(t *testing.T) {
+	// an ordinary POJO with the only field "name" is encoded like an enum
+	RegisterPOJO(&namedPOJO{})
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(&namedPOJO{Name: "dubbo"}))
+	UnRegisterPOJOs(&namedPOJO{})
+
+	// it's decoded into map if it's not registered
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{ClassKey: "test.model.NamedPOJO", "name": "dubbo"}, res)
+
+	d := NewDecoder(e.Buffer())
+	d.DetectJavaEnumValue = true
+	res, err = d.Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, JavaEnumValue{Class: "test.model.NamedPOJO", Name: "dubbo"}, res)
+}
+
+func TestDisallowUnknownEnum(t *testing.T) {
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(JavaEnumValue{Class: "test.model.Color", Name: "PURPLE"}))
+
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, InvalidJavaEnum, res)
+
+	d := NewDecoder(e.Buffer())
+	d.DisallowUnknownEnum = true
+	_, err = d.Decode()
+	assert.NotNil(t, err)
+}
+
+type testFruit string
+
+const (
+	testFruitApple  testFruit = "APPLE"
+	testFruitBanana testFruit = "BANANA"
+)
+
+func (testFruit) JavaClassName() string {
+	return "test.model.Fruit"
+}
+
+type fruitHolder struct {
+	Favorite testFruit            `hessian:"favorite"`
+	Backup   *testFruit           `hessian:"backup"`
+	Basket   []testFruit          `hessian:"basket"`
+	Prices   map[testFruit]int32  `hessian:"prices"`
+	Labels   map[string]testFruit `hessian:"labels"`
+	Any      interface{}          `hessian:"any"`
+	Missing  testFruit            `hessian:"missing"`
+}
+
+func (fruitHolder) JavaClassName() string {
+	return "test.model.FruitHolder"
+}
+
+func TestStringJavaEnum(t *testing.T) {
+	RegisterPOJO(&fruitHolder{})
+
+	banana := testFruitBanana
+	holder := &fruitHolder{
+		Favorite: testFruitApple,
+		Backup:   &banana,
+		Basket:   []testFruit{testFruitBanana, testFruitApple, ""},
+		Prices:   map[testFruit]int32{testFruitApple: 3},
+		Labels:   map[string]testFruit{"yellow": testFruitBanana},
+		Any:      testFruitApple,
+	}
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(holder))
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	h := res.(*fruitHolder)
+	assert.Equal(t, testFruitApple, h.Favorite)
+	assert.Equal(t, testFruitBanana, *h.Backup)
+	assert.Equal(t, holder.Basket, h.Basket)
+	assert.Equal(t, holder.Prices, h.Prices)
+	assert.Equal(t, holder.Labels, h.Labels)
+	assert.Equal(t, map[string]interface{}{ClassKey: "test.model.Fruit", "name": "APPLE"}, h.Any)
+	assert.Equal(t, testFruit(""), h.Missing)
+
+	// the zero value is encoded as null
+	nodes, err := ParseValueTree(e.Buffer())
+	assert.Nil(t, err)
+	assert.Equal(t, "missing", nodes[0].Fields[6])
+	assert.Equal(t, KindNull, nodes[0].Children[6].Kind)
+	e = NewEncoder()
+	assert.Nil(t, e.Encode([]testFruit{testFruitApple, ""}))
+	nodes, err = ParseValueTree(e.Buffer())
+	assert.Nil(t, err)
+	assert.Equal(t, KindNull, nodes[0].Children[1].Kind)
+
+	// the same bytes as the java enum
+	e = NewEncoder()
+	assert.Nil(t, e.Encode(testFruitApple))
+	expected := NewEncoder()
+	assert.Nil(t, expected.Encode(JavaEnumValue{Class: "test.model.Fruit", Name: "APPLE"}))
+	assert.Equal(t, expected.Buffer(), e.Buffer())
+
+	// the string enums can't be registered as POJOs
+	_, err = TryRegisterPOJO(testFruitApple)
+	assert.NotNil(t, err)
+	assert.Equal(t, -1, RegisterPOJO(testFruitApple))
+	_, ok := getStructInfo("test.model.Fruit")
+	assert.False(t, ok)
+}
+
+func TestRegisterJavaEnumValues(t *testing.T) {
+	RegisterJavaEnumValues("test.model.Size", []string{"SMALL", "LARGE"})
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode([]interface{}{
+		JavaEnumValue{Class: "test.model.Size", Name: "SMALL"},
+		JavaEnumValue{Class: "test.model.Size", Name: "HUGE"},
+	}))
+
+	// the registered enum is allowed in strict mode
+	d := NewStrictDecoder(e.Buffer())
+	res, err := d.Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, JavaEnumValue{Class: "test.model.Size", Name: "HUGE"}, res.([]interface{})[1])
+
+	d = NewDecoder(e.Buffer())
+	d.DisallowUnknownEnum = true
+	_, err = d.Decode()
+	assert.NotNil(t, err)
+}
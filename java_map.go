@@ -0,0 +1,161 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"reflect"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+// JavaMapObject is a go value which is the same as a java map class, like java.util.TreeMap.
+type JavaMapObject interface {
+	Get() map[interface{}]interface{}
+	Set(map[interface{}]interface{})
+	JavaClassName() string
+}
+
+var mapTypeMap = make(map[string]reflect.Type, 16)
+
+// SetMapSerialize registers a java map class, so that the map is encoded as a typed map of the class,
+// and the typed map of the class is decoded as the registered go type.
+func SetMapSerialize(m JavaMapObject) {
+	name := m.JavaClassName()
+	v := reflect.ValueOf(m)
+	var typ reflect.Type
+	switch v.Kind() {
+	case reflect.Struct:
+		typ = v.Type()
+	case reflect.Ptr:
+		typ = v.Elem().Type()
+	default:
+		typ = reflect.TypeOf(m)
+	}
+	SetSerializer(name, JavaMapSerializer{})
+	RegisterPOJO(m)
+	mapTypeMap[name] = typ
+}
+
+func getMapSerialize(name string) reflect.Type {
+	return mapTypeMap[name]
+}
+
+func isMapSerialize(name string) bool {
+	return getMapSerialize(name) != nil
+}
+
+type JavaMapSerializer struct{}
+
+// ::= 'M' type (value value)* 'Z'  # key, value map pairs
+func (JavaMapSerializer) EncObject(e *Encoder, vv POJO) error {
+	var err error
+	v, ok := vv.(JavaMapObject)
+	if !ok {
+		return perrors.New("can not be converted into java map object")
+	}
+	mapName := v.JavaClassName()
+	if mapName == "" {
+		return perrors.New("map name empty")
+	}
+
+	// check ref
+	if n, ok := e.checkRefMap(reflect.ValueOf(vv)); ok {
+		e.buffer = encRef(e.buffer, n)
+		return nil
+	}
+
+	e.buffer = encByte(e.buffer, BC_MAP)
+	e.buffer = encString(e.buffer, mapName)
+	for k, value := range v.Get() {
+		if err = e.Encode(k); err != nil {
+			return err
+		}
+		if err = e.Encode(value); err != nil {
+			return err
+		}
+	}
+	e.buffer = encByte(e.buffer, BC_END) // 'Z'
+
+	return nil
+}
+
+func (JavaMapSerializer) DecObject(d *Decoder, typ reflect.Type, cls *ClassInfo) (interface{}, error) {
+	// for the java impl of hessian encode maps as typed map, which will not be decoded as object in go impl, this method should not be called
+	return nil, perrors.New("unexpected map decode call")
+}
+
+// decodeMapObject decodes the entries of a typed map into the registered go type,
+// the map tag and type have been read.
+func (d *Decoder) decodeMapObject(typ reflect.Type) (interface{}, error) {
+	mapV, ok := reflect.New(typ).Interface().(JavaMapObject)
+	if !ok {
+		return nil, perrors.New("map deserialize err " + typ.String())
+	}
+
+	m := make(map[interface{}]interface{})
+	mapV.Set(m)
+	d.appendRefs(mapV)
+
+	for d.peekByte() != BC_END {
+		k, err := d.Decode()
+		if err != nil {
+			return nil, err
+		}
+		v, err := d.Decode()
+		if err != nil {
+			return nil, err
+		}
+		m[k] = v
+	}
+	if _, err := d.ReadByte(); err != nil {
+		return nil, perrors.WithStack(err)
+	}
+
+	mapV.Set(m)
+	return mapV, nil
+}
+
+// decMapObjectField decodes the map into the field of the type registered by SetMapSerialize or EnumMap,
+// the untyped map is converted to the java map object too.
+func (d *Decoder) decMapObjectField(typ reflect.Type) (interface{}, error) {
+	v, err := d.readMap(TAG_READ, false)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	if m, ok := v.(map[interface{}]interface{}); ok {
+		if mapV, ok := reflect.New(typ).Interface().(JavaMapObject); ok {
+			mapV.Set(m)
+			return mapV, nil
+		}
+	}
+	if UnpackPtrType(reflect.TypeOf(v)) != typ {
+		return nil, perrors.Errorf("can not decode %T into %s", v, typ)
+	}
+	return v, nil
+}
+
+// isMapObjectType checks whether the type is registered by SetMapSerialize.
+func isMapObjectType(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+	v, ok := reflect.New(typ).Interface().(JavaMapObject)
+	return ok && getMapSerialize(v.JavaClassName()) == typ
+}
@@ -0,0 +1,146 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	SetMapSerialize(&JavaTreeMap{})
+}
+
+type JavaTreeMap struct {
+	value map[interface{}]interface{}
+}
+
+func (j *JavaTreeMap) Get() map[interface{}]interface{} {
+	return j.value
+}
+
+func (j *JavaTreeMap) Set(v map[interface{}]interface{}) {
+	j.value = v
+}
+
+func (j *JavaTreeMap) JavaClassName() string {
+	return "java.util.TreeMap"
+}
+
+type JavaMapHolder struct {
+	Names *JavaTreeMap
+	Attrs map[interface{}]interface{}
+}
+
+func (JavaMapHolder) JavaClassName() string {
+	return "test.model.JavaMapHolder"
+}
+
+// javaMapHolderWire is the same class as JavaMapHolder, but both fields are typed maps
+type javaMapHolderWire struct {
+	Names *JavaTreeMap
+	Attrs *JavaTreeMap
+}
+
+func (javaMapHolderWire) JavaClassName() string {
+	return "test.model.JavaMapHolder"
+}
+
+func TestJavaMapEncode(t *testing.T) {
+	treeMap := &JavaTreeMap{value: map[interface{}]interface{}{"a": int32(1)}}
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(treeMap))
+	assert.Equal(t, append(append([]byte{BC_MAP}, encString(nil, "java.util.TreeMap")...), 0x01, 'a', 0x91, BC_END), e.Buffer())
+
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, treeMap, res)
+}
+
+func TestJavaMapDecodeField(t *testing.T) {
+	RegisterPOJO(&JavaMapHolder{})
+
+	holder := &JavaMapHolder{
+		Names: &JavaTreeMap{value: map[interface{}]interface{}{"b": "c"}},
+		Attrs: map[interface{}]interface{}{"d": int64(2)},
+	}
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(holder))
+	// the typed map of the registered class is decoded into the go map field
+	assert.Nil(t, e.Encode(&javaMapHolderWire{Attrs: holder.Names}))
+
+	d := NewDecoder(e.Buffer())
+	res, err := d.Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, holder, res)
+
+	res, err = d.Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, map[interface{}]interface{}{"b": "c"}, res.(*JavaMapHolder).Attrs)
+}
+
+func TestJavaMapDecodeUnregistered(t *testing.T) {
+	e := NewEncoder()
+	e.buffer = encByte(e.buffer, BC_MAP)
+	e.buffer = encString(e.buffer, "java.util.LinkedHashMap")
+	e.buffer = encString(e.buffer, "k")
+	e.buffer = encInt32(e.buffer, 1)
+	e.buffer = encByte(e.buffer, BC_END)
+
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, map[interface{}]interface{}{"k": int32(1)}, res)
+}
+
+type mapStructHolder struct {
+	Dept *Department
+}
+
+func (mapStructHolder) JavaClassName() string {
+	return "test.model.MapStructHolder"
+}
+
+func TestJavaMapDecodeStructField(t *testing.T) {
+	RegisterPOJO(&JavaMapHolder{})
+	RegisterPOJO(&mapStructHolder{})
+
+	// the untyped map is converted to the java map object of the field
+	e := NewEncoder()
+	assert.Nil(t, e.EncodeMapAsObject(&ClassInfo{
+		javaName:      "test.model.JavaMapHolder",
+		fieldNameList: []string{"names"},
+	}, map[string]interface{}{"names": map[interface{}]interface{}{"b": "c"}}))
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, &JavaTreeMap{value: map[interface{}]interface{}{"b": "c"}}, res.(*JavaMapHolder).Names)
+
+	// the map can not be decoded into the struct field
+	e = NewEncoder()
+	assert.Nil(t, e.EncodeMapAsObject(&ClassInfo{
+		javaName:      "test.model.MapStructHolder",
+		fieldNameList: []string{"dept"},
+	}, map[string]interface{}{"dept": map[interface{}]interface{}{"name": "dubbo"}}))
+	_, err = NewDecoder(e.Buffer()).Decode()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "field dept")
+}
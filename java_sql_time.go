@@ -35,6 +35,8 @@ func init() {
 	RegisterPOJO(&java_sql_time.Time{})
 	SetJavaSqlTimeSerialize(&java_sql_time.Date{})
 	SetJavaSqlTimeSerialize(&java_sql_time.Time{})
+	RegisterPOJO(&java_sql_time.Timestamp{})
+	SetSerializer(java_sql_time.Timestamp{}.JavaClassName(), JavaSqlTimestampSerializer{})
 }
 
 // SetJavaSqlTimeSerialize register serializer for java.sql.Time & java.sql.Date
@@ -134,3 +136,71 @@ func (JavaSqlTimeSerializer) DecObject(d *Decoder, typ reflect.Type, cls *ClassI
 	result.SetTime(date)
 	return result, nil
 }
+
+const (
+	sqlTimestampValueField = "value"
+	sqlTimestampNanosField = "nanos"
+)
+
+// JavaSqlTimestampSerializer used to encode & decode java.sql.Timestamp,
+// the value field of milliseconds is the one of java hessian, and the nanos field keeps the nanoseconds,
+// which is ignored by java hessian.
+type JavaSqlTimestampSerializer struct{}
+
+func (JavaSqlTimestampSerializer) EncObject(e *Encoder, v POJO) error {
+	ts, ok := v.(*java_sql_time.Timestamp)
+	if !ok {
+		return perrors.Errorf("unexpected timestamp type %T", v)
+	}
+	// check ref
+	if n, ok := e.checkRefMap(reflect.ValueOf(ts)); ok {
+		e.buffer = encRef(e.buffer, n)
+		return nil
+	}
+
+	idx := e.defineClass(&ClassInfo{
+		javaName:      ts.JavaClassName(),
+		fieldNameList: []string{sqlTimestampValueField, sqlTimestampNanosField},
+	})
+	e.encObjectIndex(idx)
+	e.buffer = encDateInMs(e.buffer, &ts.Time)
+	e.buffer = encInt32(e.buffer, ts.Nanos())
+	return nil
+}
+
+func (JavaSqlTimestampSerializer) DecObject(d *Decoder, typ reflect.Type, cls *ClassInfo) (interface{}, error) {
+	ts := &java_sql_time.Timestamp{}
+	d.appendRefs(ts)
+
+	var (
+		nanos    int32
+		hasNanos bool
+	)
+	for _, fieldName := range cls.fieldNameList {
+		switch fieldName {
+		case sqlTimestampValueField:
+			date, err := d.decDate(TAG_READ)
+			if err != nil {
+				return nil, perrors.Wrapf(err, "decode Timestamp field %s", fieldName)
+			}
+			ts.SetTime(date)
+		case sqlTimestampNanosField:
+			value, err := d.Decode()
+			if err != nil {
+				return nil, perrors.Wrapf(err, "decode Timestamp field %s", fieldName)
+			}
+			if nanos, hasNanos = value.(int32); !hasNanos {
+				return nil, perrors.Errorf("expect int Timestamp nanos, but get %T", value)
+			}
+		default:
+			if _, err := d.Decode(); err != nil {
+				return nil, perrors.Wrapf(err, "decode Timestamp field %s", fieldName)
+			}
+		}
+	}
+
+	if hasNanos {
+		ts.SetNanos(nanos)
+	}
+	return ts, nil
+}
@@ -0,0 +1,75 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package java_sql_time
+
+import (
+	"strings"
+	"time"
+)
+
+// Timestamp is java.sql.Timestamp, which keeps the nanoseconds of the time.
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp creates the Timestamp of the time.
+func NewTimestamp(t time.Time) *Timestamp {
+	return &Timestamp{Time: t}
+}
+
+func (Timestamp) JavaClassName() string {
+	return "java.sql.Timestamp"
+}
+
+func (t *Timestamp) GetTime() time.Time {
+	return t.Time
+}
+
+func (t *Timestamp) SetTime(time time.Time) {
+	t.Time = time
+}
+
+// Nanos returns the nanoseconds of the second, the same as Timestamp.getNanos() of java.
+func (t *Timestamp) Nanos() int32 {
+	return int32(t.Time.Nanosecond())
+}
+
+// SetNanos sets the nanoseconds of the second, the same as Timestamp.setNanos(int) of java.
+func (t *Timestamp) SetNanos(nanos int32) {
+	t.Time = t.Time.Truncate(time.Second).Add(time.Duration(nanos))
+}
+
+// ValueOf parses the format '2006-01-02 15:04:05.999999999' of Timestamp.valueOf(String) of java in the local location,
+// the fraction of second is optional.
+func (t *Timestamp) ValueOf(timestampStr string) error {
+	time, err := time.ParseInLocation("2006-01-02 15:04:05.999999999", timestampStr, time.Local)
+	if err != nil {
+		return err
+	}
+	t.Time = time
+	return nil
+}
+
+// String returns the same format as Timestamp.toString() of java, like '2006-01-02 15:04:05.123'.
+func (t *Timestamp) String() string {
+	s := t.Time.Format("2006-01-02 15:04:05.999999999")
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
@@ -100,3 +100,30 @@ func TestJavaSqlTimeWithGo(t *testing.T) {
 	resultSqlDate, _ := d.Decode()
 	assert.Equal(t, &sqlDate, resultSqlDate)
 }
+
+func TestJavaSqlTimestamp(t *testing.T) {
+	ts := &java_sql_time.Timestamp{}
+	assert.Nil(t, ts.ValueOf("2021-03-04 05:06:07.123456789"))
+	assert.Equal(t, int32(123456789), ts.Nanos())
+	assert.Equal(t, "2021-03-04 05:06:07.123456789", ts.String())
+	assert.Nil(t, ts.ValueOf("2021-03-04 05:06:07"))
+	assert.Equal(t, "2021-03-04 05:06:07.0", ts.String())
+	ts.SetNanos(500)
+	assert.Equal(t, "2021-03-04 05:06:07.0000005", ts.String())
+
+	now := time.Now()
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(java_sql_time.NewTimestamp(now)))
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.True(t, now.Equal(res.(*java_sql_time.Timestamp).Time))
+
+	// java hessian only writes the value of milliseconds
+	e = NewEncoder()
+	idx := e.defineClass(&ClassInfo{javaName: "java.sql.Timestamp", fieldNameList: []string{"value"}})
+	e.encObjectIndex(idx)
+	e.buffer = encDateInMs(e.buffer, &now)
+	res, err = NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.True(t, now.Truncate(time.Millisecond).Equal(res.(*java_sql_time.Timestamp).Time))
+}
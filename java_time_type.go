@@ -0,0 +1,225 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"github.com/apache/dubbo-go-hessian2/java8_time"
+	"github.com/apache/dubbo-go-hessian2/java_sql_time"
+	"github.com/apache/dubbo-go-hessian2/java_util"
+)
+
+// JavaTimeType is the java type which time.Time is encoded as.
+type JavaTimeType int
+
+const (
+	// JavaTimeDate encodes time.Time as java.util.Date in milliseconds, it's the default one.
+	JavaTimeDate JavaTimeType = iota
+	// JavaTimeInstant encodes time.Time as java.time.Instant.
+	JavaTimeInstant
+	// JavaTimeLocalDateTime encodes the wall clock of time.Time as java.time.LocalDateTime.
+	JavaTimeLocalDateTime
+	// JavaTimeLocalDate encodes the date of time.Time as java.time.LocalDate.
+	JavaTimeLocalDate
+	// JavaTimeOffsetDateTime encodes time.Time as java.time.OffsetDateTime.
+	JavaTimeOffsetDateTime
+	// JavaTimeZonedDateTime encodes time.Time as java.time.ZonedDateTime with the zone of its location.
+	JavaTimeZonedDateTime
+	// JavaTimeTimestamp encodes time.Time as java.sql.Timestamp.
+	JavaTimeTimestamp
+)
+
+var javaTimeTypeNames = map[string]JavaTimeType{
+	"date":           JavaTimeDate,
+	"instant":        JavaTimeInstant,
+	"localdatetime":  JavaTimeLocalDateTime,
+	"localdate":      JavaTimeLocalDate,
+	"offsetdatetime": JavaTimeOffsetDateTime,
+	"zoneddatetime":  JavaTimeZonedDateTime,
+	"timestamp":      JavaTimeTimestamp,
+}
+
+// JavaDurationType is the java type which time.Duration is encoded as.
+type JavaDurationType int
+
+const (
+	// JavaDurationLong encodes time.Duration as a long of nanoseconds, it's the default one.
+	JavaDurationLong JavaDurationType = iota
+	// JavaDurationJava8 encodes time.Duration as java.time.Duration.
+	JavaDurationJava8
+)
+
+var javaDurationTypeNames = map[string]JavaDurationType{
+	"long":     JavaDurationLong,
+	"duration": JavaDurationJava8,
+}
+
+// the tag options to choose the java type of the time fields, e.g. `hessian:"createTime,time=Instant"`
+// and `hessian:"timeout,duration=Duration"`, the values are case insensitive.
+const (
+	timeTagOption     = "time"
+	durationTagOption = "duration"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// encTime encodes time.Time as the java type, the zero time is encoded as null.
+func (e *Encoder) encTime(t time.Time, typ JavaTimeType) error {
+	if t == ZeroDate {
+		e.buffer = EncNull(e.buffer)
+		return nil
+	}
+
+	switch typ {
+	case JavaTimeDate:
+		e.buffer = encDateInMs(e.buffer, &t)
+		return nil
+	case JavaTimeInstant:
+		v := java8_time.InstantFromTime(t)
+		return e.Encode(&v)
+	case JavaTimeLocalDateTime:
+		v := java8_time.LocalDateTimeOf(t)
+		return e.Encode(&v)
+	case JavaTimeLocalDate:
+		v := java8_time.LocalDateOf(t)
+		return e.Encode(&v)
+	case JavaTimeOffsetDateTime:
+		v := java8_time.OffsetDateTimeOf(t)
+		return e.Encode(&v)
+	case JavaTimeZonedDateTime:
+		v := java8_time.ZonedDateTimeOf(t)
+		return e.Encode(&v)
+	case JavaTimeTimestamp:
+		return e.Encode(java_sql_time.NewTimestamp(t))
+	default:
+		return perrors.Errorf("unknown java time type %d", typ)
+	}
+}
+
+// encDuration encodes time.Duration as the java type.
+func (e *Encoder) encDuration(d time.Duration, typ JavaDurationType) error {
+	switch typ {
+	case JavaDurationLong:
+		e.buffer = encInt64(e.buffer, int64(d))
+		return nil
+	case JavaDurationJava8:
+		v := java8_time.DurationFromStd(d)
+		return e.Encode(&v)
+	default:
+		return perrors.Errorf("unknown java duration type %d", typ)
+	}
+}
+
+// encTimeField encodes the time.Time or time.Duration field, the java type can be chosen by the tag.
+func (e *Encoder) encTimeField(tag fieldTag, field reflect.Value) error {
+	v := UnpackPtrValue(field)
+	if v.Kind() == reflect.Ptr {
+		// nil pointer
+		e.buffer = EncNull(e.buffer)
+		return nil
+	}
+
+	if v.Type() == timeType {
+		typ := e.TimeType
+		if name, ok := tag.option(timeTagOption); ok {
+			if typ, ok = javaTimeTypeNames[strings.ToLower(name)]; !ok {
+				return perrors.Errorf("unknown java time type %s", name)
+			}
+		}
+		return e.encTime(v.Interface().(time.Time), typ)
+	}
+
+	typ := e.DurationType
+	if name, ok := tag.option(durationTagOption); ok {
+		if typ, ok = javaDurationTypeNames[strings.ToLower(name)]; !ok {
+			return perrors.Errorf("unknown java duration type %s", name)
+		}
+	}
+	return e.encDuration(time.Duration(v.Int()), typ)
+}
+
+// decTimeField decodes the value of a time.Time field, which may be a date or one of the java time objects.
+func (d *Decoder) decTimeField() (time.Time, error) {
+	tag := d.peekByte()
+	if tag == BC_NULL || tag == BC_DATE || tag == BC_DATE_MINUTE {
+		return d.decDate(TAG_READ)
+	}
+
+	v, err := d.DecodeValue()
+	if err != nil {
+		return ZeroDate, err
+	}
+	return toTime(v)
+}
+
+// toTime converts the decoded java time object to time.Time, the local times are in the local location.
+func toTime(v interface{}) (time.Time, error) {
+	switch t := EnsureRawAny(v).(type) {
+	case nil:
+		return ZeroDate, nil
+	case time.Time:
+		return t, nil
+	case *java8_time.Instant:
+		return t.Time(), t.Validate()
+	case *java8_time.LocalDateTime:
+		return t.In(time.Local), t.Validate()
+	case *java8_time.LocalDate:
+		return t.In(time.Local), t.Validate()
+	case *java8_time.OffsetDateTime:
+		return t.Time(), t.Validate()
+	case *java8_time.ZonedDateTime:
+		return t.Time()
+	case *java_util.Calendar:
+		return t.Time(), nil
+	case java_sql_time.JavaSqlTime:
+		return t.GetTime(), nil
+	}
+	return ZeroDate, perrors.Errorf("can not convert %T to time.Time", v)
+}
+
+// decDurationField decodes the value of a time.Duration field, which may be a long or a java.time.Duration.
+func (d *Decoder) decDurationField() (time.Duration, error) {
+	v, err := d.DecodeValue()
+	if err != nil {
+		return 0, err
+	}
+
+	switch t := EnsureRawAny(v).(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		return time.Duration(t), nil
+	case int32:
+		return time.Duration(t), nil
+	case *java8_time.Duration:
+		return t.ToStd()
+	}
+	return 0, perrors.Errorf("can not convert %T to time.Duration", v)
+}
@@ -0,0 +1,137 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+import (
+	"github.com/apache/dubbo-go-hessian2/java8_time"
+)
+
+type timeTypeHolder struct {
+	Created   time.Time     `hessian:"created"`
+	Instant   time.Time     `hessian:"instant,time=Instant"`
+	Local     time.Time     `hessian:"local,time=LocalDateTime"`
+	Day       *time.Time    `hessian:"day,time=localDate"`
+	Offset    time.Time     `hessian:"offset,time=OffsetDateTime"`
+	Zoned     time.Time     `hessian:"zoned,time=ZonedDateTime"`
+	Timestamp time.Time     `hessian:"timestamp,time=Timestamp"`
+	Missing   *time.Time    `hessian:"missing,time=Instant"`
+	Timeout   time.Duration `hessian:"timeout"`
+	Interval  time.Duration `hessian:"interval,duration=Duration"`
+}
+
+func (timeTypeHolder) JavaClassName() string {
+	return "test.model.TimeTypeHolder"
+}
+
+func TestEncodeTimeType(t *testing.T) {
+	RegisterPOJO(&timeTypeHolder{})
+
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	assert.Nil(t, err)
+	now := time.Date(2021, 6, 16, 8, 30, 15, 123456789, loc)
+	day := time.Date(2021, 6, 16, 0, 0, 0, 0, time.Local)
+	holder := &timeTypeHolder{
+		Created:   now,
+		Instant:   now,
+		Local:     now.In(time.Local),
+		Day:       &day,
+		Offset:    now,
+		Zoned:     now,
+		Timestamp: now,
+		Timeout:   3 * time.Second,
+		Interval:  -1500 * time.Millisecond,
+	}
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(holder))
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	h := res.(*timeTypeHolder)
+	assert.True(t, now.Truncate(time.Millisecond).Equal(h.Created))
+	assert.True(t, now.Equal(h.Instant))
+	assert.True(t, now.Equal(h.Local))
+	assert.Equal(t, day, *h.Day)
+	assert.True(t, now.Equal(h.Offset))
+	assert.Equal(t, now, h.Zoned)
+	assert.True(t, now.Equal(h.Timestamp))
+	assert.True(t, h.Missing == nil || h.Missing.IsZero())
+	assert.Equal(t, 3*time.Second, h.Timeout)
+	assert.Equal(t, -1500*time.Millisecond, h.Interval)
+
+	nodes, err := ParseValueTree(e.Buffer())
+	assert.Nil(t, err)
+	fields := nodes[0].Children
+	assert.Equal(t, KindDate, fields[0].Kind)
+	assert.Equal(t, java8_time.Instant{}.JavaClassName(), fields[1].Type)
+	assert.Equal(t, java8_time.LocalDateTime{}.JavaClassName(), fields[2].Type)
+	assert.Equal(t, java8_time.LocalDate{}.JavaClassName(), fields[3].Type)
+	assert.Equal(t, java8_time.OffsetDateTime{}.JavaClassName(), fields[4].Type)
+	assert.Equal(t, java8_time.ZonedDateTime{}.JavaClassName(), fields[5].Type)
+	assert.Equal(t, "java.sql.Timestamp", fields[6].Type)
+	assert.Equal(t, KindNull, fields[7].Kind)
+	assert.Equal(t, KindLong, fields[8].Kind)
+	assert.Equal(t, java8_time.Duration{}.JavaClassName(), fields[9].Type)
+}
+
+func TestEncodeTimeTypeOption(t *testing.T) {
+	now := time.Unix(1623832215, 1).UTC()
+
+	e := NewEncoder()
+	e.TimeType = JavaTimeInstant
+	e.DurationType = JavaDurationJava8
+	assert.Nil(t, e.Encode(now))
+	assert.Nil(t, e.Encode(time.Nanosecond))
+
+	d := NewDecoder(e.Buffer())
+	res, err := d.Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, now, res.(*java8_time.Instant).Time())
+	res, err = d.Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, &java8_time.Duration{Seconds: 0, Nanos: 1}, res)
+
+	// the tag option overrides the encoder option
+	e = NewEncoder()
+	e.TimeType = JavaTimeZonedDateTime
+	assert.Nil(t, e.Encode(&timeTypeHolder{Instant: now, Created: now}))
+	nodes, err := ParseValueTree(e.Buffer())
+	assert.Nil(t, err)
+	assert.Equal(t, java8_time.ZonedDateTime{}.JavaClassName(), nodes[0].Children[0].Type)
+	assert.Equal(t, java8_time.Instant{}.JavaClassName(), nodes[0].Children[1].Type)
+}
+
+type badTimeTypeHolder struct {
+	Created time.Time `hessian:"created,time=Calendar"`
+}
+
+func (badTimeTypeHolder) JavaClassName() string {
+	return "test.model.BadTimeTypeHolder"
+}
+
+func TestEncodeTimeTypeUnknown(t *testing.T) {
+	assert.NotNil(t, NewEncoder().Encode(&badTimeTypeHolder{Created: time.Now()}))
+}
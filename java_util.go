@@ -22,10 +22,25 @@ import (
 )
 
 func init() {
-	RegisterPOJO(&java_util.UUID{
-		Value: "",
-	})
 	RegisterPOJO(&java_util.LocaleHandle{
 		Value: "",
 	})
+	RegisterPOJO(&java_util.Optional{})
+	RegisterPOJO(&java_util.OptionalInt{})
+	RegisterPOJO(&java_util.OptionalLong{})
+	RegisterPOJO(&java_util.OptionalDouble{})
+	RegisterPOJO(&java_util.BitSet{})
+	// the CalendarHandle of the caucho hessian is decoded too, the later registered class is used to encode.
+	RegisterPOJOMapping("com.caucho.hessian.io.CalendarHandle", &java_util.Calendar{})
+	RegisterPOJO(&java_util.Calendar{})
+	RegisterPOJOMapping("java.util.SimpleTimeZone", &java_util.TimeZone{})
+	RegisterPOJO(&java_util.TimeZone{})
+}
+
+// RegisterJavaUtilSets registers java.util.HashSet, java.util.LinkedHashSet and java.util.TreeSet, so that they are
+// decoded as *java_util.HashSet and so on, otherwise the java sets are decoded as []interface{}.
+func RegisterJavaUtilSets() {
+	SetCollectionSerialize(&java_util.HashSet{})
+	SetCollectionSerialize(&java_util.LinkedHashSet{})
+	SetCollectionSerialize(&java_util.TreeSet{})
 }
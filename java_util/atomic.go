@@ -0,0 +1,161 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package java_util
+
+import (
+	"sync/atomic"
+)
+
+// java.util.concurrent.atomic.AtomicInteger, it's safe for concurrent use.
+type AtomicInteger struct {
+	value int32
+}
+
+// NewAtomicInteger creates a java.util.concurrent.atomic.AtomicInteger of the value.
+func NewAtomicInteger(v int32) *AtomicInteger {
+	return &AtomicInteger{value: v}
+}
+
+func (AtomicInteger) JavaClassName() string {
+	return "java.util.concurrent.atomic.AtomicInteger"
+}
+
+// Get returns the value.
+func (a *AtomicInteger) Get() int32 {
+	return atomic.LoadInt32(&a.value)
+}
+
+// Set sets the value.
+func (a *AtomicInteger) Set(v int32) {
+	atomic.StoreInt32(&a.value, v)
+}
+
+// Add adds delta to the value and returns the new value.
+func (a *AtomicInteger) Add(delta int32) int32 {
+	return atomic.AddInt32(&a.value, delta)
+}
+
+// CompareAndSet sets the value to new if the current value is old.
+func (a *AtomicInteger) CompareAndSet(old, new int32) bool {
+	return atomic.CompareAndSwapInt32(&a.value, old, new)
+}
+
+// java.util.concurrent.atomic.AtomicLong, it's safe for concurrent use.
+type AtomicLong struct {
+	value int64
+}
+
+// NewAtomicLong creates a java.util.concurrent.atomic.AtomicLong of the value.
+func NewAtomicLong(v int64) *AtomicLong {
+	return &AtomicLong{value: v}
+}
+
+func (AtomicLong) JavaClassName() string {
+	return "java.util.concurrent.atomic.AtomicLong"
+}
+
+// Get returns the value.
+func (a *AtomicLong) Get() int64 {
+	return atomic.LoadInt64(&a.value)
+}
+
+// Set sets the value.
+func (a *AtomicLong) Set(v int64) {
+	atomic.StoreInt64(&a.value, v)
+}
+
+// Add adds delta to the value and returns the new value.
+func (a *AtomicLong) Add(delta int64) int64 {
+	return atomic.AddInt64(&a.value, delta)
+}
+
+// CompareAndSet sets the value to new if the current value is old.
+func (a *AtomicLong) CompareAndSet(old, new int64) bool {
+	return atomic.CompareAndSwapInt64(&a.value, old, new)
+}
+
+// java.util.concurrent.atomic.AtomicBoolean, it's safe for concurrent use.
+// The value is an int in java, 1 for true and 0 for false.
+type AtomicBoolean struct {
+	value int32
+}
+
+// NewAtomicBoolean creates a java.util.concurrent.atomic.AtomicBoolean of the value.
+func NewAtomicBoolean(v bool) *AtomicBoolean {
+	return &AtomicBoolean{value: boolToInt32(v)}
+}
+
+func (AtomicBoolean) JavaClassName() string {
+	return "java.util.concurrent.atomic.AtomicBoolean"
+}
+
+// Get returns the value.
+func (a *AtomicBoolean) Get() bool {
+	return atomic.LoadInt32(&a.value) != 0
+}
+
+// Set sets the value.
+func (a *AtomicBoolean) Set(v bool) {
+	atomic.StoreInt32(&a.value, boolToInt32(v))
+}
+
+// CompareAndSet sets the value to new if the current value is old.
+func (a *AtomicBoolean) CompareAndSet(old, new bool) bool {
+	return atomic.CompareAndSwapInt32(&a.value, boolToInt32(old), boolToInt32(new))
+}
+
+func boolToInt32(v bool) int32 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// java.util.concurrent.atomic.AtomicReference, it's safe for concurrent use.
+type AtomicReference struct {
+	value atomic.Value
+}
+
+// atomicRef boxes the value, so that nil and values of different types can be stored in atomic.Value.
+type atomicRef struct {
+	v interface{}
+}
+
+// NewAtomicReference creates a java.util.concurrent.atomic.AtomicReference of the value.
+func NewAtomicReference(v interface{}) *AtomicReference {
+	a := &AtomicReference{}
+	a.Set(v)
+	return a
+}
+
+func (AtomicReference) JavaClassName() string {
+	return "java.util.concurrent.atomic.AtomicReference"
+}
+
+// Get returns the value.
+func (a *AtomicReference) Get() interface{} {
+	if ref, ok := a.value.Load().(atomicRef); ok {
+		return ref.v
+	}
+	return nil
+}
+
+// Set sets the value.
+func (a *AtomicReference) Set(v interface{}) {
+	a.value.Store(atomicRef{v: v})
+}
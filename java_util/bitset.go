@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package java_util
+
+import (
+	"math/bits"
+)
+
+// BitSet is java.util.BitSet, the bits are stored in the words like the java impl,
+// the bit i is the bit (i % 64) of the word (i / 64).
+type BitSet struct {
+	Words []int64 `hessian:"words"`
+}
+
+// NewBitSet creates a BitSet with the given bits set.
+func NewBitSet(indexes ...int) *BitSet {
+	b := &BitSet{}
+	for _, i := range indexes {
+		b.Set(i)
+	}
+	return b
+}
+
+func (BitSet) JavaClassName() string {
+	return "java.util.BitSet"
+}
+
+// Set sets the bit at the index to true.
+func (b *BitSet) Set(i int) {
+	if i < 0 {
+		return
+	}
+	w := i >> 6
+	for len(b.Words) <= w {
+		b.Words = append(b.Words, 0)
+	}
+	b.Words[w] |= 1 << uint(i&63)
+}
+
+// Clear sets the bit at the index to false.
+func (b *BitSet) Clear(i int) {
+	w := i >> 6
+	if i < 0 || w >= len(b.Words) {
+		return
+	}
+	b.Words[w] &^= 1 << uint(i&63)
+	// remove the unused words like java does
+	n := len(b.Words)
+	for n > 0 && b.Words[n-1] == 0 {
+		n--
+	}
+	b.Words = b.Words[:n]
+}
+
+// Get returns the bit at the index.
+func (b *BitSet) Get(i int) bool {
+	w := i >> 6
+	if i < 0 || w >= len(b.Words) {
+		return false
+	}
+	return b.Words[w]&(1<<uint(i&63)) != 0
+}
+
+// Len returns the index of the highest set bit plus one.
+func (b *BitSet) Len() int {
+	for w := len(b.Words) - 1; w >= 0; w-- {
+		if b.Words[w] != 0 {
+			return w*64 + bits.Len64(uint64(b.Words[w]))
+		}
+	}
+	return 0
+}
+
+// Cardinality returns the number of bits set to true.
+func (b *BitSet) Cardinality() int {
+	n := 0
+	for _, w := range b.Words {
+		n += bits.OnesCount64(uint64(w))
+	}
+	return n
+}
+
+// Indexes returns the indexes of the bits set to true in ascending order.
+func (b *BitSet) Indexes() []int {
+	indexes := make([]int, 0, b.Cardinality())
+	for w, word := range b.Words {
+		u := uint64(word)
+		for u != 0 {
+			indexes = append(indexes, w*64+bits.TrailingZeros64(u))
+			u &= u - 1
+		}
+	}
+	return indexes
+}
@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package java_util
+
+import (
+	"time"
+)
+
+import (
+	"github.com/apache/dubbo-go-hessian2/java8_time"
+	"github.com/apache/dubbo-go-hessian2/java_exception"
+)
+
+// Calendar is java.util.Calendar, which is written as a CalendarHandle object of the calendar class and the date by java hessian.
+// The date of hessian has no time zone, so the zone id is kept in the extra field "timeZone", which is ignored by java.
+type Calendar struct {
+	Type     *java_exception.Class `hessian:"type"`
+	Date     time.Time             `hessian:"date"`
+	TimeZone string                `hessian:"timeZone"`
+}
+
+// NewCalendar creates a java.util.GregorianCalendar of the time, keeping the location of it.
+func NewCalendar(t time.Time) *Calendar {
+	return &Calendar{
+		Type:     &java_exception.Class{Name: "java.util.GregorianCalendar"},
+		Date:     t,
+		TimeZone: timeZoneID(t),
+	}
+}
+
+func (Calendar) JavaClassName() string {
+	return "com.alibaba.com.caucho.hessian.io.CalendarHandle"
+}
+
+// Time returns the date in the location of the time zone, the local location is used if the zone is unknown.
+func (c Calendar) Time() time.Time {
+	if c.TimeZone == "" {
+		return c.Date
+	}
+	loc, err := java8_time.ZoneId{ZoneId: c.TimeZone}.Location()
+	if err != nil {
+		return c.Date
+	}
+	return c.Date.In(loc)
+}
+
+// TimeZone is java.util.TimeZone, which is a sun.util.calendar.ZoneInfo in java.
+// Only the id and the raw offset in milliseconds are kept.
+type TimeZone struct {
+	ID        string `hessian:"ID"`
+	RawOffset int32  `hessian:"rawOffset"`
+}
+
+// NewTimeZone creates a TimeZone of the location, the raw offset is the offset without daylight saving.
+func NewTimeZone(loc *time.Location) *TimeZone {
+	year := time.Now().Year()
+	_, winter := time.Date(year, time.January, 1, 0, 0, 0, 0, loc).Zone()
+	_, summer := time.Date(year, time.July, 1, 0, 0, 0, 0, loc).Zone()
+	offset := winter
+	if summer < winter {
+		offset = summer
+	}
+	return &TimeZone{ID: timeZoneID(time.Now().In(loc)), RawOffset: int32(offset * 1000)}
+}
+
+func (TimeZone) JavaClassName() string {
+	return "sun.util.calendar.ZoneInfo"
+}
+
+// Location returns the location of the id, a fixed zone of the raw offset is returned if the id is unknown.
+func (z TimeZone) Location() *time.Location {
+	if loc, err := time.LoadLocation(z.ID); err == nil {
+		return loc
+	}
+	return time.FixedZone(z.ID, int(z.RawOffset/1000))
+}
+
+// timeZoneID returns the java time zone id of the location of the time like java8_time.ZoneIDOf,
+// but the offset ids are written as the custom ids of java.util.TimeZone, e.g. "GMT+08:00".
+func timeZoneID(t time.Time) string {
+	id := java8_time.ZoneIDOf(t)
+	if id == "Z" {
+		return "GMT"
+	}
+	if id[0] == '+' || id[0] == '-' {
+		return "GMT" + id
+	}
+	return id
+}
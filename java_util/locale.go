@@ -17,6 +17,14 @@
 
 package java_util
 
+import (
+	"strings"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
 // LocaleEnum is Locale enumeration value
 type LocaleEnum int
 
@@ -46,27 +54,199 @@ const (
 	ROOT
 )
 
+// customLocale is the id of the locales which are not the predefined ones
+const customLocale LocaleEnum = -1
+
 // Locale => java.util.Locale
 type Locale struct {
 	// ID is used to implement enumeration
-	id     LocaleEnum
-	lang   string
-	county string
+	id      LocaleEnum
+	lang    string
+	county  string
+	script  string
+	variant string
+}
+
+// NewLocale creates a Locale like java.util.Locale.Builder, the language is lower case,
+// the script is title case and the country is upper case. The predefined Locale is returned if it matches.
+func NewLocale(lang, script, country, variant string) *Locale {
+	locale := Locale{
+		id:      customLocale,
+		lang:    strings.ToLower(lang),
+		county:  strings.ToUpper(country),
+		variant: variant,
+	}
+	if script != "" {
+		locale.script = strings.ToUpper(script[:1]) + strings.ToLower(script[1:])
+	}
+	if predefined, ok := localeMap[locale.String()]; ok && locale.script == "" && locale.variant == "" {
+		return &predefined
+	}
+	return &locale
 }
 
 func (locale *Locale) County() string {
 	return locale.county
 }
 
+// Country returns the country or region code.
+func (locale *Locale) Country() string {
+	return locale.county
+}
+
 func (locale *Locale) Lang() string {
 	return locale.lang
 }
 
+// Script returns the ISO 15924 script code like "Hans".
+func (locale *Locale) Script() string {
+	return locale.script
+}
+
+// Variant returns the variant.
+func (locale *Locale) Variant() string {
+	return locale.variant
+}
+
+// String returns the same string as Locale.toString() of java, like "en_US" and "zh_CN_#Hans", without the extensions.
 func (locale *Locale) String() string {
-	if len(locale.county) != 0 {
-		return locale.lang + "_" + locale.county
+	l, s, r, v := locale.lang != "", locale.script != "", locale.county != "", locale.variant != ""
+	result := locale.lang
+	if r || (l && (v || s)) {
+		result += "_" + locale.county
 	}
-	return locale.lang
+	if v && (l || r) {
+		result += "_" + locale.variant
+	}
+	if s && (l || r) {
+		result += "_#" + locale.script
+	}
+	return result
+}
+
+// ToLanguageTag returns the BCP 47 language tag like "zh-Hans-CN", which can be parsed by golang.org/x/text/language.
+func (locale *Locale) ToLanguageTag() string {
+	lang := locale.lang
+	if lang == "" {
+		lang = "und"
+	}
+	parts := []string{lang}
+	if locale.script != "" {
+		parts = append(parts, locale.script)
+	}
+	if locale.county != "" {
+		parts = append(parts, locale.county)
+	}
+	for _, variant := range strings.Split(locale.variant, "_") {
+		if isLanguageTagVariant(variant) {
+			parts = append(parts, variant)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// ParseLocale parses the string of Locale.toString() of java, like "en_US", "zh_CN_#Hans" and "ja_JP_JP_#u-ca-japanese".
+// The extensions after "_#" are ignored.
+func ParseLocale(s string) (*Locale, error) {
+	var script string
+	if i := strings.Index(s, "_#"); i >= 0 {
+		s, script = s[:i], s[i+2:]
+		if j := strings.IndexByte(script, '_'); j >= 0 {
+			script = script[:j]
+		}
+		// java writes the script first if there is one, otherwise the extensions
+		if !isLanguageTagScript(script) {
+			script = ""
+		}
+	}
+
+	parts := strings.SplitN(s, "_", 3)
+	var country, variant string
+	if len(parts) > 1 {
+		country = parts[1]
+	}
+	if len(parts) > 2 {
+		variant = parts[2]
+	}
+	// the variant is free-form in java, only the language and the country are checked
+	if !isAlpha(parts[0]) || !isAlphaNum(country) {
+		return nil, perrors.Errorf("invalid locale: %s", s)
+	}
+	return NewLocale(parts[0], script, country, variant), nil
+}
+
+// ForLanguageTag parses the BCP 47 language tag like "zh-Hans-CN", the underscores are accepted as separators too,
+// and the extensions and the private use subtags are ignored.
+func ForLanguageTag(tag string) (*Locale, error) {
+	subtags := strings.FieldsFunc(tag, func(r rune) bool { return r == '-' || r == '_' })
+	if len(subtags) == 0 {
+		return nil, perrors.Errorf("invalid language tag: %s", tag)
+	}
+
+	lang := subtags[0]
+	if !isAlpha(lang) || len(lang) < 2 || len(lang) > 8 {
+		return nil, perrors.Errorf("invalid language of tag: %s", tag)
+	}
+	if strings.EqualFold(lang, "und") {
+		lang = ""
+	}
+
+	var (
+		script   string
+		country  string
+		variants []string
+	)
+	i := 1
+	if i < len(subtags) && isLanguageTagScript(subtags[i]) {
+		script = subtags[i]
+		i++
+	}
+	if i < len(subtags) && (len(subtags[i]) == 2 && isAlpha(subtags[i]) || len(subtags[i]) == 3 && isDigit(subtags[i])) {
+		country = subtags[i]
+		i++
+	}
+	for ; i < len(subtags) && len(subtags[i]) > 1; i++ {
+		if !isLanguageTagVariant(subtags[i]) {
+			return nil, perrors.Errorf("invalid subtag %s of tag: %s", subtags[i], tag)
+		}
+		variants = append(variants, subtags[i])
+	}
+	return NewLocale(lang, script, country, strings.Join(variants, "_")), nil
+}
+
+func isLanguageTagScript(s string) bool {
+	return len(s) == 4 && isAlpha(s)
+}
+
+func isLanguageTagVariant(s string) bool {
+	return isAlphaNum(s) && (len(s) >= 5 && len(s) <= 8 || len(s) == 4 && s[0] >= '0' && s[0] <= '9')
+}
+
+func isAlpha(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i] | 0x20; c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphaNum(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isAlpha(s[i:i+1]) && !isDigit(s[i:i+1]) {
+			return false
+		}
+	}
+	return true
 }
 
 // LocaleHandle => com.alibaba.com.caucho.hessian.io.LocaleHandle object
@@ -195,8 +375,20 @@ func ToLocale(e LocaleEnum) *Locale {
 	return &locales[e]
 }
 
-// GetLocaleFromHandler is use LocaleHandle get Locale
+// GetLocaleFromHandler is use LocaleHandle get Locale, the predefined Locale is returned if it matches.
 func GetLocaleFromHandler(localeHandler *LocaleHandle) *Locale {
-	locale := localeMap[localeHandler.Value]
-	return &locale
+	if locale, ok := localeMap[localeHandler.Value]; ok {
+		return &locale
+	}
+	locale, err := ParseLocale(localeHandler.Value)
+	if err != nil {
+		// keep the value as the language, it's still the same string
+		return &Locale{id: customLocale, lang: localeHandler.Value}
+	}
+	return locale
+}
+
+// NewLocaleHandle creates the LocaleHandle of the Locale, which is the way java hessian writes a Locale.
+func NewLocaleHandle(locale *Locale) *LocaleHandle {
+	return &LocaleHandle{Value: locale.String()}
 }
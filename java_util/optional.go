@@ -0,0 +1,132 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package java_util
+
+import (
+	"reflect"
+)
+
+// java.util.Optional, the value is nil if it's empty.
+type Optional struct {
+	Value interface{} `hessian:"value"`
+}
+
+// NewOptional creates a java.util.Optional of the value, it's empty if the value is nil or a nil pointer.
+func NewOptional(v interface{}) *Optional {
+	if v != nil {
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			v = nil
+		}
+	}
+	return &Optional{Value: v}
+}
+
+func (Optional) JavaClassName() string {
+	return "java.util.Optional"
+}
+
+// IsPresent checks whether the value is present.
+func (o Optional) IsPresent() bool {
+	return o.Value != nil
+}
+
+// Get returns the value, it's nil if the optional is empty.
+func (o Optional) Get() interface{} {
+	return o.Value
+}
+
+// java.util.OptionalInt
+type OptionalInt struct {
+	Present bool  `hessian:"isPresent"`
+	Value   int32 `hessian:"value"`
+}
+
+// NewOptionalInt creates a java.util.OptionalInt, it's empty if the pointer is nil.
+func NewOptionalInt(v *int32) *OptionalInt {
+	if v == nil {
+		return &OptionalInt{}
+	}
+	return &OptionalInt{Present: true, Value: *v}
+}
+
+func (OptionalInt) JavaClassName() string {
+	return "java.util.OptionalInt"
+}
+
+// ToPtr returns the pointer of the value, it's nil if the optional is empty.
+func (o OptionalInt) ToPtr() *int32 {
+	if !o.Present {
+		return nil
+	}
+	v := o.Value
+	return &v
+}
+
+// java.util.OptionalLong
+type OptionalLong struct {
+	Present bool  `hessian:"isPresent"`
+	Value   int64 `hessian:"value"`
+}
+
+// NewOptionalLong creates a java.util.OptionalLong, it's empty if the pointer is nil.
+func NewOptionalLong(v *int64) *OptionalLong {
+	if v == nil {
+		return &OptionalLong{}
+	}
+	return &OptionalLong{Present: true, Value: *v}
+}
+
+func (OptionalLong) JavaClassName() string {
+	return "java.util.OptionalLong"
+}
+
+// ToPtr returns the pointer of the value, it's nil if the optional is empty.
+func (o OptionalLong) ToPtr() *int64 {
+	if !o.Present {
+		return nil
+	}
+	v := o.Value
+	return &v
+}
+
+// java.util.OptionalDouble
+type OptionalDouble struct {
+	Present bool    `hessian:"isPresent"`
+	Value   float64 `hessian:"value"`
+}
+
+// NewOptionalDouble creates a java.util.OptionalDouble, it's empty if the pointer is nil.
+func NewOptionalDouble(v *float64) *OptionalDouble {
+	if v == nil {
+		return &OptionalDouble{}
+	}
+	return &OptionalDouble{Present: true, Value: *v}
+}
+
+func (OptionalDouble) JavaClassName() string {
+	return "java.util.OptionalDouble"
+}
+
+// ToPtr returns the pointer of the value, it's nil if the optional is empty.
+func (o OptionalDouble) ToPtr() *float64 {
+	if !o.Present {
+		return nil
+	}
+	v := o.Value
+	return &v
+}
@@ -0,0 +1,187 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package java_util
+
+import (
+	"reflect"
+)
+
+// javaSet is a set keeping the order of the added values, the values which are not comparable
+// like maps and slices are checked by deep equal.
+type javaSet struct {
+	values []interface{}
+	index  map[interface{}]struct{}
+}
+
+func (s *javaSet) find(v interface{}) int {
+	for i, value := range s.values {
+		if reflect.DeepEqual(value, v) {
+			return i
+		}
+	}
+	return -1
+}
+
+// isComparable checks whether the value can be a map key. The comparable types like interfaces, arrays and structs
+// may hold the values which are not comparable, so the dynamic value is checked.
+func isComparable(v interface{}) bool {
+	return isComparableValue(reflect.ValueOf(v))
+}
+
+func isComparableValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Interface:
+		return isComparableValue(v.Elem())
+	case reflect.Array:
+		if !v.Type().Comparable() {
+			return false
+		}
+		for i := 0; i < v.Len(); i++ {
+			if !isComparableValue(v.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		if !v.Type().Comparable() {
+			return false
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if !isComparableValue(v.Field(i)) {
+				return false
+			}
+		}
+		return true
+	default:
+		return v.Type().Comparable()
+	}
+}
+
+// Add adds the value, it returns false if the value exists.
+func (s *javaSet) Add(v interface{}) bool {
+	if s.Contains(v) {
+		return false
+	}
+	if isComparable(v) {
+		if s.index == nil {
+			s.index = make(map[interface{}]struct{})
+		}
+		s.index[v] = struct{}{}
+	}
+	s.values = append(s.values, v)
+	return true
+}
+
+// Remove removes the value, it returns false if the value doesn't exist.
+func (s *javaSet) Remove(v interface{}) bool {
+	if !s.Contains(v) {
+		return false
+	}
+	if isComparable(v) {
+		delete(s.index, v)
+	}
+	i := s.find(v)
+	s.values = append(s.values[:i], s.values[i+1:]...)
+	return true
+}
+
+// Contains checks whether the value exists.
+func (s *javaSet) Contains(v interface{}) bool {
+	if isComparable(v) {
+		_, ok := s.index[v]
+		return ok
+	}
+	return s.find(v) != -1
+}
+
+// Len returns the number of values.
+func (s *javaSet) Len() int {
+	return len(s.values)
+}
+
+// Values returns the values in the order they are added.
+func (s *javaSet) Values() []interface{} {
+	values := make([]interface{}, len(s.values))
+	copy(values, s.values)
+	return values
+}
+
+// Get returns the values, it's used to encode the set as a java collection.
+func (s *javaSet) Get() []interface{} {
+	return s.values
+}
+
+// Set replaces the values with the de-duplicated given values, it's used to decode the set from a java collection.
+func (s *javaSet) Set(values []interface{}) {
+	s.values = make([]interface{}, 0, len(values))
+	s.index = make(map[interface{}]struct{}, len(values))
+	for _, v := range values {
+		s.Add(v)
+	}
+}
+
+// java.util.HashSet
+type HashSet struct {
+	javaSet
+}
+
+// NewHashSet creates a java.util.HashSet of the values.
+func NewHashSet(values ...interface{}) *HashSet {
+	s := &HashSet{}
+	s.Set(values)
+	return s
+}
+
+func (HashSet) JavaClassName() string {
+	return "java.util.HashSet"
+}
+
+// java.util.LinkedHashSet
+type LinkedHashSet struct {
+	javaSet
+}
+
+// NewLinkedHashSet creates a java.util.LinkedHashSet of the values.
+func NewLinkedHashSet(values ...interface{}) *LinkedHashSet {
+	s := &LinkedHashSet{}
+	s.Set(values)
+	return s
+}
+
+func (LinkedHashSet) JavaClassName() string {
+	return "java.util.LinkedHashSet"
+}
+
+// java.util.TreeSet. The values aren't sorted in go, they are kept in the added order,
+// the java side sorts them by its comparator when decoding, and the decoded values are in the java order.
+type TreeSet struct {
+	javaSet
+}
+
+// NewTreeSet creates a java.util.TreeSet of the values, the values aren't sorted.
+func NewTreeSet(values ...interface{}) *TreeSet {
+	s := &TreeSet{}
+	s.Set(values)
+	return s
+}
+
+func (TreeSet) JavaClassName() string {
+	return "java.util.TreeSet"
+}
@@ -17,6 +17,16 @@
 
 package java_util
 
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
 // java.util.UUID
 type UUID struct {
 	Value string `hessian:"value"`
@@ -30,3 +40,63 @@ func (UUID) JavaClassName() string {
 func (uuid UUID) String() string {
 	return uuid.Value
 }
+
+// NewUUID creates the UUID of the 16 bytes in big endian, like the java.util.UUID of the most and least significant bits.
+func NewUUID(b [16]byte) *UUID {
+	var buf [36]byte
+	hex.Encode(buf[0:8], b[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], b[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], b[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], b[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], b[10:])
+	return &UUID{Value: string(buf[:])}
+}
+
+// UUIDFromBits creates the UUID of the most and least significant bits, which are the fields of java.util.UUID.
+func UUIDFromBits(mostSigBits, leastSigBits int64) *UUID {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(mostSigBits))
+	binary.BigEndian.PutUint64(b[8:], uint64(leastSigBits))
+	return NewUUID(b)
+}
+
+// ParseUUID parses the canonical form "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx" of the UUID, the value is in lower case.
+func ParseUUID(s string) (*UUID, error) {
+	b, err := UUID{Value: s}.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return NewUUID(b), nil
+}
+
+// Bytes returns the 16 bytes of the UUID in big endian.
+func (uuid UUID) Bytes() ([16]byte, error) {
+	var b [16]byte
+	s := uuid.Value
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return b, perrors.Errorf("invalid UUID string: %q", s)
+	}
+	if _, err := hex.Decode(b[:], []byte(strings.Replace(s, "-", "", -1))); err != nil {
+		return b, perrors.Wrapf(err, "invalid UUID string: %q", s)
+	}
+	return b, nil
+}
+
+// Bits returns the most and least significant bits of the UUID.
+func (uuid UUID) Bits() (mostSigBits, leastSigBits int64, err error) {
+	b, err := uuid.Bytes()
+	if err != nil {
+		return 0, 0, err
+	}
+	return int64(binary.BigEndian.Uint64(b[:8])), int64(binary.BigEndian.Uint64(b[8:])), nil
+}
+
+// Validate checks whether the value is the canonical form of UUID.
+func (uuid UUID) Validate() error {
+	_, err := uuid.Bytes()
+	return err
+}
@@ -19,6 +19,7 @@ package hessian
 
 import (
 	"testing"
+	"time"
 )
 
 import (
@@ -54,6 +55,51 @@ func TestJavaUtil(t *testing.T) {
 	assert.Equal(t, (resUuid2.(*java_util.UUID)).String(), resUuid2String)
 }
 
+func TestJavaUtilUUID(t *testing.T) {
+	uuid, err := java_util.ParseUUID("065EC58D-A89F-4B64-9C9F-D223EA2E73B6")
+	assert.Nil(t, err)
+	assert.Equal(t, "065ec58d-a89f-4b64-9c9f-d223ea2e73b6", uuid.String())
+
+	most, least, err := uuid.Bits()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0x065ec58da89f4b64), most)
+	assert.Equal(t, int64(-0x6360_2ddc_15d1_8c4a), least)
+	assert.Equal(t, uuid, java_util.UUIDFromBits(most, least))
+
+	b, err := uuid.Bytes()
+	assert.Nil(t, err)
+	assert.Equal(t, byte(0x06), b[0])
+	assert.Equal(t, byte(0xb6), b[15])
+	assert.Equal(t, uuid, java_util.NewUUID(b))
+
+	for _, s := range []string{"", "065ec58d-a89f-4b64-9c9f-d223ea2e73b", "065ec58da89f-4b64-9c9f-d223ea2e73b6-", "065ec58d-a89f-4b64-9c9f-d223ea2e73bx"} {
+		_, err = java_util.ParseUUID(s)
+		assert.NotNil(t, err, s)
+	}
+
+	// both layouts are decoded
+	for _, format := range []UUIDFormat{UUIDFormatString, UUIDFormatBits} {
+		e := NewEncoder()
+		e.UUIDFormat = format
+		assert.Nil(t, e.Encode(uuid))
+
+		res, err := NewDecoder(e.Buffer()).Decode()
+		assert.Nil(t, err)
+		assert.Equal(t, uuid, res)
+	}
+
+	e := NewEncoder()
+	e.UUIDFormat = UUIDFormatBits
+	assert.Nil(t, e.Encode(uuid))
+	nodes, err := ParseValueTree(e.Buffer())
+	assert.Nil(t, err)
+	assert.Equal(t, "java.util.UUID", nodes[0].Type)
+	assert.Equal(t, []string{"mostSigBits", "leastSigBits"}, nodes[0].Fields)
+
+	e = NewEncoder()
+	e.UUIDFormat = UUIDFormatBits
+	assert.NotNil(t, e.Encode(&java_util.UUID{Value: "not-uuid"}))
+}
 func TestJavaUtilLocale(t *testing.T) {
 	res, err := decodeJavaResponse(`customReplyLocale`, ``, false)
 	if err != nil {
@@ -126,3 +172,242 @@ func TestJavaUtilLocale(t *testing.T) {
 	assert.Equal(t, java_util.ToLocale(java_util.CANADA), java_util.GetLocaleFromHandler(canada.(*java_util.LocaleHandle)))
 	assert.Equal(t, java_util.ToLocale(java_util.ROOT), java_util.GetLocaleFromHandler(root.(*java_util.LocaleHandle)))
 }
+
+func TestJavaUtilLocaleParse(t *testing.T) {
+	for _, s := range []string{"pt_BR", "zh_CN_#Hans", "ja_JP_JP", "sr__#Latn", "de__POSIX", "_GB", "en"} {
+		l, err := java_util.ParseLocale(s)
+		assert.Nil(t, err)
+		assert.Equal(t, s, l.String())
+	}
+
+	l, err := java_util.ParseLocale("zh_CN_#Hans")
+	assert.Nil(t, err)
+	assert.Equal(t, "zh", l.Lang())
+	assert.Equal(t, "Hans", l.Script())
+	assert.Equal(t, "CN", l.Country())
+	assert.Equal(t, "zh-Hans-CN", l.ToLanguageTag())
+
+	// the predefined locales are kept
+	l, err = java_util.ParseLocale("en_US")
+	assert.Nil(t, err)
+	assert.Equal(t, java_util.ToLocale(java_util.US), l)
+
+	// the extensions are skipped
+	for s, expected := range map[string]string{
+		"ja_JP_JP_#u-ca-japanese": "ja_JP_JP",
+		"th_TH_TH_#u-nu-thai":     "th_TH_TH",
+		"de_DE_#u-co-phonebk":     "de_DE",
+		"sr_RS_#Latn_u-nu-latn":   "sr_RS_#Latn",
+	} {
+		l, err := java_util.ParseLocale(s)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, l.String())
+	}
+	_, err = java_util.ParseLocale("1_CN")
+	assert.NotNil(t, err)
+
+	for tag, expected := range map[string]string{
+		"zh-Hant-TW":     "zh_TW_#Hant",
+		"pt_BR":          "pt_BR",
+		"und-US":         "_US",
+		"de-CH-1996":     "de_CH_1996",
+		"en-US-x-custom": "en_US",
+		"es-419":         "es_419",
+	} {
+		l, err := java_util.ForLanguageTag(tag)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, l.String())
+	}
+	_, err = java_util.ForLanguageTag("1-US")
+	assert.NotNil(t, err)
+
+	l = java_util.NewLocale("SR", "latn", "rs", "")
+	assert.Equal(t, "sr-Latn-RS", l.ToLanguageTag())
+	assert.Equal(t, "und", java_util.NewLocale("", "", "", "").ToLanguageTag())
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(java_util.NewLocaleHandle(l)))
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, l, java_util.GetLocaleFromHandler(res.(*java_util.LocaleHandle)))
+}
+
+func TestJavaUtilOptional(t *testing.T) {
+	i32, i64, f64 := int32(1), int64(2), 3.5
+	for _, v := range []interface{}{
+		java_util.NewOptional("a"),
+		java_util.NewOptional((*string)(nil)),
+		java_util.NewOptionalInt(&i32),
+		java_util.NewOptionalInt(nil),
+		java_util.NewOptionalLong(&i64),
+		java_util.NewOptionalDouble(&f64),
+	} {
+		e := NewEncoder()
+		assert.Nil(t, e.Encode(v))
+
+		res, err := NewDecoder(e.Buffer()).Decode()
+		assert.Nil(t, err)
+		assert.Equal(t, v, res)
+	}
+
+	assert.False(t, java_util.NewOptional((*string)(nil)).IsPresent())
+	assert.Equal(t, "a", java_util.NewOptional("a").Get())
+	assert.Equal(t, &i32, java_util.NewOptionalInt(&i32).ToPtr())
+	assert.Nil(t, java_util.NewOptionalLong(nil).ToPtr())
+	assert.Equal(t, &f64, java_util.NewOptionalDouble(&f64).ToPtr())
+
+	// the same field layout as the java impl
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(java_util.NewOptionalInt(&i32)))
+	nodes, err := ParseValueTree(e.Buffer())
+	assert.Nil(t, err)
+	assert.Equal(t, "java.util.OptionalInt", nodes[0].Type)
+	assert.Equal(t, []string{"isPresent", "value"}, nodes[0].Fields)
+}
+
+type atomicHolder struct {
+	Count   java_util.AtomicInteger
+	Total   *java_util.AtomicLong
+	Enabled *java_util.AtomicBoolean
+	Latest  *java_util.AtomicReference
+}
+
+func (atomicHolder) JavaClassName() string {
+	return "test.model.AtomicHolder"
+}
+
+func TestJavaUtilAtomic(t *testing.T) {
+	RegisterPOJO(&atomicHolder{})
+
+	holder := &atomicHolder{
+		Total:   java_util.NewAtomicLong(1 << 40),
+		Enabled: java_util.NewAtomicBoolean(true),
+		Latest:  java_util.NewAtomicReference("v1"),
+	}
+	holder.Count.Set(3)
+	assert.Equal(t, int32(5), holder.Count.Add(2))
+	assert.True(t, holder.Enabled.CompareAndSet(true, true))
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(holder))
+	assert.Nil(t, e.Encode(java_util.NewAtomicReference(nil)))
+
+	d := NewDecoder(e.Buffer())
+	res, err := d.Decode()
+	assert.Nil(t, err)
+	h := res.(*atomicHolder)
+	assert.Equal(t, int32(5), h.Count.Get())
+	assert.Equal(t, int64(1<<40), h.Total.Get())
+	assert.True(t, h.Enabled.Get())
+	assert.Equal(t, "v1", h.Latest.Get())
+
+	res, err = d.Decode()
+	assert.Nil(t, err)
+	assert.Nil(t, res.(*java_util.AtomicReference).Get())
+
+	// the same layout as the java impl, the value of AtomicBoolean is an int
+	nodes, err := ParseValueTree(e.Buffer())
+	assert.Nil(t, err)
+	enabled := nodes[0].Children[2]
+	assert.Equal(t, "java.util.concurrent.atomic.AtomicBoolean", enabled.Type)
+	assert.Equal(t, []string{"value"}, enabled.Fields)
+	assert.Equal(t, int32(1), enabled.Children[0].Value)
+}
+
+type bitSetHolder struct {
+	Flags *java_util.BitSet `hessian:"flags"`
+}
+
+func (bitSetHolder) JavaClassName() string {
+	return "test.model.BitSetHolder"
+}
+
+func TestJavaUtilBitSet(t *testing.T) {
+	RegisterPOJO(&bitSetHolder{})
+
+	bs := java_util.NewBitSet(1, 3, 64, 130)
+	assert.True(t, bs.Get(64))
+	assert.False(t, bs.Get(2))
+	assert.Equal(t, 4, bs.Cardinality())
+	assert.Equal(t, 131, bs.Len())
+	bs.Clear(130)
+	assert.Equal(t, []int64{0xa, 1}, bs.Words)
+	assert.Equal(t, []int{1, 3, 64}, bs.Indexes())
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(&bitSetHolder{Flags: bs}))
+
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, bs, res.(*bitSetHolder).Flags)
+
+	// the same layout as the java impl, a long[] words field
+	nodes, err := ParseValueTree(e.Buffer())
+	assert.Nil(t, err)
+	flags := nodes[0].Children[0]
+	assert.Equal(t, "java.util.BitSet", flags.Type)
+	assert.Equal(t, []string{"words"}, flags.Fields)
+	assert.Equal(t, "[long", flags.Children[0].Type)
+}
+
+type calendarHolder struct {
+	Created *java_util.Calendar `hessian:"created"`
+	Zone    *java_util.TimeZone `hessian:"zone"`
+}
+
+func (calendarHolder) JavaClassName() string {
+	return "test.model.CalendarHolder"
+}
+
+func TestJavaUtilCalendar(t *testing.T) {
+	RegisterPOJO(&calendarHolder{})
+
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	assert.Nil(t, err)
+	created := time.Date(2021, 6, 16, 8, 30, 15, 123e6, loc)
+	holder := &calendarHolder{
+		Created: java_util.NewCalendar(created),
+		Zone:    java_util.NewTimeZone(loc),
+	}
+	assert.Equal(t, int32(8*3600*1000), holder.Zone.RawOffset)
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(holder))
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	h := res.(*calendarHolder)
+	assert.Equal(t, "java.util.GregorianCalendar", h.Created.Type.Name)
+	assert.Equal(t, created, h.Created.Time())
+	assert.Equal(t, "Asia/Shanghai", h.Created.Time().Location().String())
+	assert.Equal(t, loc, h.Zone.Location())
+
+	// the unknown zone id falls back to the raw offset
+	zone := java_util.TimeZone{ID: "GMT+08:30", RawOffset: 8*3600*1000 + 1800*1000}
+	_, offset := created.In(zone.Location()).Zone()
+	assert.Equal(t, 8*3600+1800, offset)
+
+	// the fixed zone without a name is sent as a custom id of java
+	fixed := time.FixedZone("", 8*3600)
+	assert.Equal(t, "GMT+08:00", java_util.NewTimeZone(fixed).ID)
+	calendar := java_util.NewCalendar(created.In(fixed))
+	assert.Equal(t, "GMT+08:00", calendar.TimeZone)
+	_, offset = calendar.Time().Zone()
+	assert.Equal(t, 8*3600, offset)
+	assert.Equal(t, "GMT", java_util.NewTimeZone(time.FixedZone("", 0)).ID)
+
+	// the local location isn't sent as "Local"
+	local := java_util.NewCalendar(created.In(time.Local))
+	assert.NotEqual(t, "Local", local.TimeZone)
+	assert.True(t, created.Equal(local.Time()))
+	assert.NotEqual(t, "Local", java_util.NewTimeZone(time.Local).ID)
+
+	// the java ZoneInfo has more fields
+	e = NewEncoder()
+	assert.Nil(t, e.EncodeMapAsObject(&ClassInfo{
+		javaName:      "sun.util.calendar.ZoneInfo",
+		fieldNameList: []string{"checksum", "rawOffset", "transitions", "ID"},
+	}, map[string]interface{}{"checksum": int32(1), "rawOffset": int32(0), "transitions": []int64{1, 2}, "ID": "UTC"}))
+	res, err = NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, &java_util.TimeZone{ID: "UTC"}, res)
+}
@@ -0,0 +1,128 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"reflect"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"github.com/apache/dubbo-go-hessian2/java_util"
+)
+
+func init() {
+	RegisterPOJO(&java_util.UUID{})
+	SetSerializer(java_util.UUID{}.JavaClassName(), UUIDSerializer{})
+}
+
+// UUIDFormat is the layout which java.util.UUID is encoded in.
+type UUIDFormat int
+
+const (
+	// UUIDFormatString encodes java.util.UUID as an object with the value field of the string, like the dubbo hessian-lite.
+	// It's the default one.
+	UUIDFormatString UUIDFormat = iota
+	// UUIDFormatBits encodes java.util.UUID as an object with the mostSigBits and leastSigBits fields, like the caucho hessian.
+	UUIDFormatBits
+)
+
+const (
+	uuidValueField        = "value"
+	uuidMostSigBitsField  = "mostSigBits"
+	uuidLeastSigBitsField = "leastSigBits"
+)
+
+// UUIDSerializer encodes java_util.UUID in the layout of Encoder.UUIDFormat, and decodes both layouts.
+type UUIDSerializer struct{}
+
+func (UUIDSerializer) EncObject(e *Encoder, v POJO) error {
+	uuid, ok := v.(*java_util.UUID)
+	if !ok {
+		return perrors.Errorf("unexpected uuid type %T", v)
+	}
+	// check ref
+	if n, ok := e.checkRefMap(reflect.ValueOf(uuid)); ok {
+		e.buffer = encRef(e.buffer, n)
+		return nil
+	}
+
+	if e.UUIDFormat != UUIDFormatBits {
+		idx := e.defineClass(&ClassInfo{javaName: uuid.JavaClassName(), fieldNameList: []string{uuidValueField}})
+		e.encObjectIndex(idx)
+		return e.Encode(uuid.Value)
+	}
+
+	most, least, err := uuid.Bits()
+	if err != nil {
+		return err
+	}
+	idx := e.defineClass(&ClassInfo{
+		javaName:      uuid.JavaClassName(),
+		fieldNameList: []string{uuidMostSigBitsField, uuidLeastSigBitsField},
+	})
+	e.encObjectIndex(idx)
+	e.buffer = encInt64(e.buffer, most)
+	e.buffer = encInt64(e.buffer, least)
+	return nil
+}
+
+func (UUIDSerializer) DecObject(d *Decoder, typ reflect.Type, cls *ClassInfo) (interface{}, error) {
+	uuid := &java_util.UUID{}
+	d.appendRefs(uuid)
+
+	var (
+		most, least int64
+		hasBits     bool
+	)
+	for _, fieldName := range cls.fieldNameList {
+		value, err := d.Decode()
+		if err != nil {
+			return nil, perrors.Wrapf(err, "decode UUID field %s", fieldName)
+		}
+		switch fieldName {
+		case uuidValueField:
+			if value != nil {
+				s, ok := value.(string)
+				if !ok {
+					return nil, perrors.Errorf("expect string UUID value, but get %T", value)
+				}
+				uuid.Value = s
+			}
+		case uuidMostSigBitsField, uuidLeastSigBitsField:
+			bits, ok := value.(int64)
+			if !ok {
+				return nil, perrors.Errorf("expect long UUID %s, but get %T", fieldName, value)
+			}
+			if fieldName == uuidMostSigBitsField {
+				most = bits
+			} else {
+				least = bits
+			}
+			hasBits = true
+		}
+	}
+
+	if hasBits {
+		uuid.Value = java_util.UUIDFromBits(most, least).Value
+	}
+	return uuid, nil
+}
@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package java_value
+
+import (
+	"net"
+)
+
+// InetAddress is java.net.InetAddress, which is written as an InetAddressHandle object by java hessian.
+type InetAddress struct {
+	HostName string `hessian:"hostName"`
+	Address  []byte `hessian:"address"`
+}
+
+// NewInetAddress creates an InetAddress with the host name and the ip, IPv4 addresses are kept in 4 bytes like java.
+func NewInetAddress(hostName string, ip net.IP) *InetAddress {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	return &InetAddress{HostName: hostName, Address: []byte(ip)}
+}
+
+func (InetAddress) JavaClassName() string {
+	return "com.alibaba.com.caucho.hessian.io.InetAddressHandle"
+}
+
+// IP returns the address as a go ip.
+func (a InetAddress) IP() net.IP {
+	return net.IP(a.Address)
+}
+
+// String returns the address like java, which is "hostName/ip".
+func (a InetAddress) String() string {
+	ip := ""
+	if len(a.Address) > 0 {
+		ip = a.IP().String()
+	}
+	return a.HostName + "/" + ip
+}
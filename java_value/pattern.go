@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package java_value
+
+import (
+	"regexp"
+)
+
+// the flags of java.util.regex.Pattern which can be converted to go regexp flags.
+const (
+	PatternCaseInsensitive int32 = 0x02
+	PatternMultiline       int32 = 0x08
+	PatternDotAll          int32 = 0x20
+)
+
+// Pattern is java.util.regex.Pattern, the java impl serializes the fields "pattern" and "flags".
+type Pattern struct {
+	Pattern string `hessian:"pattern"`
+	Flags   int32  `hessian:"flags"`
+}
+
+// NewPattern creates a Pattern of the regular expression with the flags.
+func NewPattern(expr string, flags int32) *Pattern {
+	return &Pattern{Pattern: expr, Flags: flags}
+}
+
+func (Pattern) JavaClassName() string {
+	return "java.util.regex.Pattern"
+}
+
+// String returns the regular expression.
+func (p Pattern) String() string {
+	return p.Pattern
+}
+
+// Compile compiles the pattern to a go regexp, the supported flags are converted to the inline flags,
+// the syntax which go doesn't support like back references fails to compile.
+func (p Pattern) Compile() (*regexp.Regexp, error) {
+	flags := ""
+	if p.Flags&PatternCaseInsensitive != 0 {
+		flags += "i"
+	}
+	if p.Flags&PatternMultiline != 0 {
+		flags += "m"
+	}
+	if p.Flags&PatternDotAll != 0 {
+		flags += "s"
+	}
+	if flags == "" {
+		return regexp.Compile(p.Pattern)
+	}
+	return regexp.Compile("(?" + flags + ")" + p.Pattern)
+}
@@ -0,0 +1,72 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package java_value provides the common value classes of JDK, which are encoded
+// in the same layout as java hessian.
+package java_value
+
+import (
+	"net/url"
+)
+
+// URL is java.net.URL, it's written as a string value object like the StringValueSerializer of java hessian.
+type URL struct {
+	Value string `hessian:"value"`
+}
+
+// NewURL creates a URL from the go url.
+func NewURL(u *url.URL) *URL {
+	return &URL{Value: u.String()}
+}
+
+func (URL) JavaClassName() string {
+	return "java.net.URL"
+}
+
+// String returns the url string.
+func (u URL) String() string {
+	return u.Value
+}
+
+// Parse parses the value to a go url.
+func (u URL) Parse() (*url.URL, error) {
+	return url.Parse(u.Value)
+}
+
+// URI is java.net.URI, the java impl only serializes the field "string".
+type URI struct {
+	Value string `hessian:"string"`
+}
+
+// NewURI creates a URI from the go url.
+func NewURI(u *url.URL) *URI {
+	return &URI{Value: u.String()}
+}
+
+func (URI) JavaClassName() string {
+	return "java.net.URI"
+}
+
+// String returns the uri string.
+func (u URI) String() string {
+	return u.Value
+}
+
+// Parse parses the value to a go url.
+func (u URI) Parse() (*url.URL, error) {
+	return url.Parse(u.Value)
+}
@@ -0,0 +1,114 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"net"
+	"net/url"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+import (
+	"github.com/apache/dubbo-go-hessian2/java_value"
+)
+
+type jdkValueHolder struct {
+	Site     *java_value.URL         `hessian:"site"`
+	Resource *java_value.URI         `hessian:"resource"`
+	Host     *java_value.InetAddress `hessian:"host"`
+	Home     *java_value.File        `hessian:"home"`
+	Filter   *java_value.Pattern     `hessian:"filter"`
+	Price    *java_value.Currency    `hessian:"price"`
+}
+
+func (jdkValueHolder) JavaClassName() string {
+	return "test.model.JdkValueHolder"
+}
+
+func TestJavaValue(t *testing.T) {
+	RegisterPOJO(&jdkValueHolder{})
+
+	u, err := url.Parse("https://dubbo.apache.org/docs?lang=en")
+	assert.Nil(t, err)
+	holder := &jdkValueHolder{
+		Site:     java_value.NewURL(u),
+		Resource: java_value.NewURI(u),
+		Host:     java_value.NewInetAddress("localhost", net.ParseIP("127.0.0.1")),
+		Home:     &java_value.File{Path: "/home/dubbo"},
+		Filter:   java_value.NewPattern("^dubbo.*", java_value.PatternCaseInsensitive),
+		Price:    &java_value.Currency{CurrencyCode: "EUR"},
+	}
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(holder))
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	h := res.(*jdkValueHolder)
+	assert.Equal(t, holder, h)
+
+	parsed, err := h.Site.Parse()
+	assert.Nil(t, err)
+	assert.Equal(t, u, parsed)
+	assert.Equal(t, []byte{127, 0, 0, 1}, h.Host.Address)
+	assert.Equal(t, "localhost/127.0.0.1", h.Host.String())
+	re, err := h.Filter.Compile()
+	assert.Nil(t, err)
+	assert.True(t, re.MatchString("Dubbo-go"))
+
+	// the same layout as java hessian
+	nodes, err := ParseValueTree(e.Buffer())
+	assert.Nil(t, err)
+	fields := nodes[0].Children
+	assert.Equal(t, []string{"value"}, fields[0].Fields)
+	assert.Equal(t, []string{"string"}, fields[1].Fields)
+	assert.Equal(t, "com.alibaba.com.caucho.hessian.io.InetAddressHandle", fields[2].Type)
+	assert.Equal(t, []string{"hostName", "address"}, fields[2].Fields)
+	assert.Equal(t, []string{"pattern", "flags"}, fields[4].Fields)
+	assert.Equal(t, []string{"currencyCode"}, fields[5].Fields)
+}
+
+func TestJavaValueCauchoInetAddress(t *testing.T) {
+	e := NewEncoder()
+	assert.Nil(t, e.EncodeMapAsObject(&ClassInfo{
+		javaName:      "com.caucho.hessian.io.InetAddressHandle",
+		fieldNameList: []string{"hostName", "address"},
+	}, map[string]interface{}{"hostName": "dubbo", "address": []byte{10, 0, 0, 1}}))
+
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, java_value.NewInetAddress("dubbo", net.IPv4(10, 0, 0, 1)), res)
+}
+
+func TestJavaValueFromJava(t *testing.T) {
+	res, err := decodeJavaResponse(`customReplyJdkValues`, ``, false)
+	assert.Nil(t, err)
+	m := res.(map[interface{}]interface{})
+
+	u, err := url.Parse("https://dubbo.apache.org/docs?lang=en")
+	assert.Nil(t, err)
+	assert.Equal(t, java_value.NewURL(u), m["url"])
+	assert.Equal(t, java_value.NewURI(u), m["uri"])
+	assert.Equal(t, java_value.NewInetAddress("localhost", net.IPv4(127, 0, 0, 1)), m["host"])
+	assert.Equal(t, &java_value.File{Path: "/home/dubbo"}, m["file"])
+	assert.Equal(t, java_value.NewPattern("^dubbo.*", java_value.PatternCaseInsensitive), m["pattern"])
+	assert.Equal(t, &java_value.Currency{CurrencyCode: "EUR"}, m["currency"])
+}
@@ -0,0 +1,650 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+// the special members of json objects, which keep the hessian types lost in json.
+const (
+	// JSONLongKey marks a long value, eg: {"_long": 1}
+	JSONLongKey = "_long"
+	// JSONDoubleKey marks a double value which can't be a json number, eg: {"_double": "NaN"}
+	JSONDoubleKey = "_double"
+	// JSONDateKey marks a date value in RFC 3339 format, eg: {"_date": "2020-09-13T12:26:40Z"}
+	JSONDateKey = "_date"
+	// JSONBinaryKey marks a binary value in base64, eg: {"_binary": "AQID"}
+	JSONBinaryKey = "_binary"
+	// JSONRefKey marks a ref to the nth list, map or object, eg: {"_ref": 0}
+	JSONRefKey = "_ref"
+	// JSONTypeKey is the type of a typed list or map, eg: {"_type": "[int", "_list": [1, 2]}
+	JSONTypeKey = "_type"
+	// JSONListKey is the items of a typed list
+	JSONListKey = "_list"
+	// JSONMapKey is the entries of a typed map whose keys are strings, eg: {"_type": "java.util.HashMap", "_map": {"a": 1}}
+	JSONMapKey = "_map"
+	// JSONEntriesKey is the entries of a map whose keys are not strings, eg: {"_entries": [[1, "a"]]}
+	JSONEntriesKey = "_entries"
+)
+
+// HessianToJSON converts a hessian value stream into a json array of the values.
+// The objects are converted to json objects with a ClassKey member, and the typed lists and maps have a JSONTypeKey member.
+// The refs are annotated as {"_ref": n} where n is the index of the referred list, map or object in the stream.
+// If resolveRefs is true, the refs are replaced with the referred values, except the ones to their ancestors.
+func HessianToJSON(data []byte, resolveRefs bool) ([]byte, error) {
+	nodes, err := ParseValueTree(data)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &jsonWriter{}
+	if resolveRefs {
+		w.refs = make(map[int]*ValueNode)
+		for _, node := range nodes {
+			w.collectRefs(node)
+		}
+		w.ancestors = make(map[int]bool)
+	}
+
+	w.buf.WriteByte('[')
+	for i, node := range nodes {
+		if i > 0 {
+			w.buf.WriteByte(',')
+		}
+		if err = w.write(node); err != nil {
+			return nil, err
+		}
+	}
+	w.buf.WriteByte(']')
+	return w.buf.Bytes(), nil
+}
+
+// JSONToHessian converts a json array produced by HessianToJSON back into a hessian value stream.
+// The plain json numbers are encoded as int if they are integers in int32 range, as long if they are
+// other integers, otherwise as double.
+func JSONToHessian(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	node, err := readJSONValue(dec)
+	if err != nil {
+		return nil, err
+	}
+	if node.Kind != KindList || node.Type != "" {
+		return nil, perrors.New("expect a json array of hessian values")
+	}
+	if _, err = dec.Token(); err != io.EOF {
+		return nil, perrors.New("unexpected data after the json array")
+	}
+
+	e := NewEncoder()
+	for _, child := range node.Children {
+		if err = e.encValueNode(child); err != nil {
+			return nil, err
+		}
+	}
+	return e.Buffer(), nil
+}
+
+type jsonWriter struct {
+	buf bytes.Buffer
+	// refs is the ref index to value, it's nil if refs are not resolved
+	refs      map[int]*ValueNode
+	ancestors map[int]bool
+}
+
+func (w *jsonWriter) collectRefs(node *ValueNode) {
+	if node.Kind == KindList || node.Kind == KindMap || node.Kind == KindObject {
+		w.refs[node.Ref] = node
+	}
+	for _, key := range node.Keys {
+		w.collectRefs(key)
+	}
+	for _, child := range node.Children {
+		w.collectRefs(child)
+	}
+}
+
+func (w *jsonWriter) writeString(s string) {
+	b, _ := json.Marshal(s)
+	w.buf.Write(b)
+}
+
+func (w *jsonWriter) writeKey(key string) {
+	w.writeString(key)
+	w.buf.WriteByte(':')
+}
+
+// writeTagged writes a single member json object, eg: {"_long":1}
+func (w *jsonWriter) writeTagged(key, value string) {
+	w.buf.WriteByte('{')
+	w.writeKey(key)
+	w.buf.WriteString(value)
+	w.buf.WriteByte('}')
+}
+
+func (w *jsonWriter) write(node *ValueNode) error {
+	switch node.Kind {
+	case KindNull:
+		w.buf.WriteString("null")
+
+	case KindBool:
+		w.buf.WriteString(strconv.FormatBool(node.Value.(bool)))
+
+	case KindInt:
+		w.buf.WriteString(strconv.FormatInt(int64(node.Value.(int32)), 10))
+
+	case KindLong:
+		w.writeTagged(JSONLongKey, strconv.FormatInt(node.Value.(int64), 10))
+
+	case KindDouble:
+		f := node.Value.(float64)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			w.writeTagged(JSONDoubleKey, strconv.Quote(strconv.FormatFloat(f, 'g', -1, 64)))
+			break
+		}
+		// keep the decimal point, so that it's decoded as double again
+		s := strconv.FormatFloat(f, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".eE") {
+			s += ".0"
+		}
+		w.buf.WriteString(s)
+
+	case KindDate:
+		w.writeTagged(JSONDateKey, strconv.Quote(node.Value.(time.Time).Format(time.RFC3339Nano)))
+
+	case KindString:
+		w.writeString(node.Value.(string))
+
+	case KindBinary:
+		w.writeTagged(JSONBinaryKey, strconv.Quote(base64.StdEncoding.EncodeToString(node.Value.([]byte))))
+
+	case KindRef:
+		if target, ok := w.refs[node.Ref]; ok && !w.ancestors[node.Ref] {
+			return w.write(target)
+		}
+		w.writeTagged(JSONRefKey, strconv.Itoa(node.Ref))
+
+	case KindList:
+		return w.writeContainer(node, w.writeList)
+
+	case KindMap:
+		return w.writeContainer(node, w.writeMap)
+
+	case KindObject:
+		return w.writeContainer(node, w.writeObject)
+
+	default:
+		return perrors.Errorf("unknown value kind %s", node.Kind)
+	}
+	return nil
+}
+
+// writeContainer writes a list, map or object, and records it as an ancestor of its children.
+func (w *jsonWriter) writeContainer(node *ValueNode, write func(*ValueNode) error) error {
+	if w.ancestors != nil {
+		w.ancestors[node.Ref] = true
+		defer delete(w.ancestors, node.Ref)
+	}
+	return write(node)
+}
+
+func (w *jsonWriter) writeArray(nodes []*ValueNode) error {
+	w.buf.WriteByte('[')
+	for i, node := range nodes {
+		if i > 0 {
+			w.buf.WriteByte(',')
+		}
+		if err := w.write(node); err != nil {
+			return err
+		}
+	}
+	w.buf.WriteByte(']')
+	return nil
+}
+
+func (w *jsonWriter) writeList(node *ValueNode) error {
+	if node.Type == "" {
+		return w.writeArray(node.Children)
+	}
+
+	w.buf.WriteByte('{')
+	w.writeKey(JSONTypeKey)
+	w.writeString(node.Type)
+	w.buf.WriteByte(',')
+	w.writeKey(JSONListKey)
+	if err := w.writeArray(node.Children); err != nil {
+		return err
+	}
+	w.buf.WriteByte('}')
+	return nil
+}
+
+func (w *jsonWriter) writeMap(node *ValueNode) error {
+	// the keys starting with '_' may be confused with the special members
+	stringKeys := true
+	for _, key := range node.Keys {
+		if key.Kind != KindString || strings.HasPrefix(key.Value.(string), "_") {
+			stringKeys = false
+			break
+		}
+	}
+
+	if stringKeys && node.Type == "" {
+		return w.writeMembers(node)
+	}
+
+	w.buf.WriteByte('{')
+	if node.Type != "" {
+		w.writeKey(JSONTypeKey)
+		w.writeString(node.Type)
+		w.buf.WriteByte(',')
+	}
+	if stringKeys {
+		w.writeKey(JSONMapKey)
+		if err := w.writeMembers(node); err != nil {
+			return err
+		}
+		w.buf.WriteByte('}')
+		return nil
+	}
+
+	w.writeKey(JSONEntriesKey)
+	w.buf.WriteByte('[')
+	for i, key := range node.Keys {
+		if i > 0 {
+			w.buf.WriteByte(',')
+		}
+		if err := w.writeArray([]*ValueNode{key, node.Children[i]}); err != nil {
+			return err
+		}
+	}
+	w.buf.WriteString("]}")
+	return nil
+}
+
+// writeMembers writes the map whose keys are strings as a json object.
+func (w *jsonWriter) writeMembers(node *ValueNode) error {
+	w.buf.WriteByte('{')
+	for i, key := range node.Keys {
+		if i > 0 {
+			w.buf.WriteByte(',')
+		}
+		w.writeKey(key.Value.(string))
+		if err := w.write(node.Children[i]); err != nil {
+			return err
+		}
+	}
+	w.buf.WriteByte('}')
+	return nil
+}
+
+func (w *jsonWriter) writeObject(node *ValueNode) error {
+	w.buf.WriteByte('{')
+	w.writeKey(ClassKey)
+	w.writeString(node.Type)
+	for i, field := range node.Fields {
+		w.buf.WriteByte(',')
+		w.writeKey(field)
+		if err := w.write(node.Children[i]); err != nil {
+			return err
+		}
+	}
+	w.buf.WriteByte('}')
+	return nil
+}
+
+// jsonMember is a member of json object, the order of members is kept.
+type jsonMember struct {
+	key   string
+	value *ValueNode
+}
+
+// readJSONValue reads a json value into a value node, the json objects with special members are converted
+// to the hessian values they represent.
+func readJSONValue(dec *json.Decoder) (*ValueNode, error) {
+	offset := int(dec.InputOffset())
+	token, err := dec.Token()
+	if err != nil {
+		return nil, perrors.WithStack(err)
+	}
+
+	node := &ValueNode{Offset: offset, Ref: -1}
+	switch v := token.(type) {
+	case nil:
+		node.Kind = KindNull
+
+	case bool:
+		node.Kind = KindBool
+		node.Value = v
+
+	case string:
+		node.Kind = KindString
+		node.Value = v
+
+	case json.Number:
+		return jsonNumberNode(node, v)
+
+	case json.Delim:
+		if v == '[' {
+			node.Kind = KindList
+			for dec.More() {
+				child, err := readJSONValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				node.Children = append(node.Children, child)
+			}
+			_, err = dec.Token()
+			return node, perrors.WithStack(err)
+		}
+
+		var members []jsonMember
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, perrors.WithStack(err)
+			}
+			value, err := readJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			members = append(members, jsonMember{key: key.(string), value: value})
+		}
+		if _, err = dec.Token(); err != nil {
+			return nil, perrors.WithStack(err)
+		}
+		return jsonObjectNode(node, members)
+	}
+
+	return node, nil
+}
+
+func jsonNumberNode(node *ValueNode, n json.Number) (*ValueNode, error) {
+	if !strings.ContainsAny(n.String(), ".eE") {
+		i, err := n.Int64()
+		if err == nil {
+			if i >= math.MinInt32 && i <= math.MaxInt32 {
+				node.Kind = KindInt
+				node.Value = int32(i)
+			} else {
+				node.Kind = KindLong
+				node.Value = i
+			}
+			return node, nil
+		}
+	}
+
+	f, err := n.Float64()
+	if err != nil {
+		return nil, perrors.Errorf("illegal number %s at offset %d", n, node.Offset)
+	}
+	node.Kind = KindDouble
+	node.Value = f
+	return node, nil
+}
+
+func jsonObjectNode(node *ValueNode, members []jsonMember) (*ValueNode, error) {
+	get := func(key string) *ValueNode {
+		for _, m := range members {
+			if m.key == key {
+				return m.value
+			}
+		}
+		return nil
+	}
+	// the type member is optional for the typed list and map forms
+	typeMember := get(JSONTypeKey)
+	if typeMember != nil {
+		if typeMember.Kind != KindString {
+			return nil, perrors.Errorf("expect string %s at offset %d", JSONTypeKey, typeMember.Offset)
+		}
+		node.Type = typeMember.Value.(string)
+	}
+
+	if class := get(ClassKey); class != nil {
+		if class.Kind != KindString {
+			return nil, perrors.Errorf("expect string %s at offset %d", ClassKey, class.Offset)
+		}
+		node.Kind = KindObject
+		node.Type = class.Value.(string)
+		for _, m := range members {
+			if m.key == ClassKey {
+				continue
+			}
+			node.Fields = append(node.Fields, m.key)
+			node.Children = append(node.Children, m.value)
+		}
+		return node, nil
+	}
+
+	if len(members) == 1 {
+		value := members[0].value
+		switch members[0].key {
+		case JSONLongKey:
+			if value.Kind != KindInt && value.Kind != KindLong {
+				return nil, perrors.Errorf("expect integer %s at offset %d", JSONLongKey, value.Offset)
+			}
+			node.Kind = KindLong
+			node.Value = toInt64(value.Value)
+			return node, nil
+
+		case JSONDoubleKey:
+			if value.Kind != KindString {
+				return nil, perrors.Errorf("expect string %s at offset %d", JSONDoubleKey, value.Offset)
+			}
+			f, err := strconv.ParseFloat(value.Value.(string), 64)
+			if err != nil {
+				return nil, perrors.Wrapf(err, "illegal %s at offset %d", JSONDoubleKey, value.Offset)
+			}
+			node.Kind = KindDouble
+			node.Value = f
+			return node, nil
+
+		case JSONDateKey:
+			if value.Kind != KindString {
+				return nil, perrors.Errorf("expect string %s at offset %d", JSONDateKey, value.Offset)
+			}
+			t, err := time.Parse(time.RFC3339Nano, value.Value.(string))
+			if err != nil {
+				return nil, perrors.Wrapf(err, "illegal %s at offset %d", JSONDateKey, value.Offset)
+			}
+			node.Kind = KindDate
+			node.Value = t
+			return node, nil
+
+		case JSONBinaryKey:
+			if value.Kind != KindString {
+				return nil, perrors.Errorf("expect string %s at offset %d", JSONBinaryKey, value.Offset)
+			}
+			b, err := base64.StdEncoding.DecodeString(value.Value.(string))
+			if err != nil {
+				return nil, perrors.Wrapf(err, "illegal %s at offset %d", JSONBinaryKey, value.Offset)
+			}
+			node.Kind = KindBinary
+			node.Value = b
+			return node, nil
+
+		case JSONRefKey:
+			if value.Kind != KindInt || value.Value.(int32) < 0 {
+				return nil, perrors.Errorf("expect ref index %s at offset %d", JSONRefKey, value.Offset)
+			}
+			node.Kind = KindRef
+			node.Ref = int(value.Value.(int32))
+			return node, nil
+		}
+	}
+
+	if list := get(JSONListKey); list != nil {
+		if list.Kind != KindList || list.Type != "" {
+			return nil, perrors.Errorf("expect array %s at offset %d", JSONListKey, list.Offset)
+		}
+		node.Kind = KindList
+		node.Children = list.Children
+		return node, nil
+	}
+
+	if m := get(JSONMapKey); m != nil {
+		if m.Kind != KindMap || m.Type != "" {
+			return nil, perrors.Errorf("expect object %s at offset %d", JSONMapKey, m.Offset)
+		}
+		node.Kind = KindMap
+		node.Keys = m.Keys
+		node.Children = m.Children
+		return node, nil
+	}
+
+	if entries := get(JSONEntriesKey); entries != nil {
+		if entries.Kind != KindList || entries.Type != "" {
+			return nil, perrors.Errorf("expect array %s at offset %d", JSONEntriesKey, entries.Offset)
+		}
+		node.Kind = KindMap
+		for _, entry := range entries.Children {
+			if entry.Kind != KindList || entry.Type != "" || len(entry.Children) != 2 {
+				return nil, perrors.Errorf("expect [key, value] entry at offset %d", entry.Offset)
+			}
+			node.Keys = append(node.Keys, entry.Children[0])
+			node.Children = append(node.Children, entry.Children[1])
+		}
+		return node, nil
+	}
+
+	if typeMember != nil {
+		return nil, perrors.Errorf("expect %s, %s or %s with %s at offset %d",
+			JSONListKey, JSONMapKey, JSONEntriesKey, JSONTypeKey, node.Offset)
+	}
+
+	node.Kind = KindMap
+	for _, m := range members {
+		node.Keys = append(node.Keys, &ValueNode{Kind: KindString, Value: m.key, Ref: -1})
+		node.Children = append(node.Children, m.value)
+	}
+	return node, nil
+}
+
+func toInt64(v interface{}) int64 {
+	if i, ok := v.(int32); ok {
+		return int64(i)
+	}
+	return v.(int64)
+}
+
+// encValueNode encodes the value node, the objects are encoded by their class definitions
+// and the lists and maps keep their types.
+func (e *Encoder) encValueNode(node *ValueNode) error {
+	switch node.Kind {
+	case KindNull:
+		e.buffer = EncNull(e.buffer)
+
+	case KindBool:
+		e.buffer = encBool(e.buffer, node.Value.(bool))
+
+	case KindInt:
+		e.buffer = encInt32(e.buffer, node.Value.(int32))
+
+	case KindLong:
+		e.buffer = encInt64(e.buffer, node.Value.(int64))
+
+	case KindDouble:
+		e.buffer = encFloat(e.buffer, node.Value.(float64))
+
+	case KindDate:
+		e.buffer = encDateInMs(e.buffer, node.Value.(time.Time))
+
+	case KindString:
+		e.buffer = encString(e.buffer, node.Value.(string))
+
+	case KindBinary:
+		e.buffer = encBinary(e.buffer, node.Value.([]byte))
+
+	case KindRef:
+		e.buffer = encRef(e.buffer, node.Ref)
+
+	case KindList:
+		if node.Type == "" {
+			e.buffer = encByte(e.buffer, BC_LIST_FIXED_UNTYPED)
+		} else {
+			e.buffer = encByte(e.buffer, BC_LIST_FIXED)
+			e.buffer = encString(e.buffer, node.Type)
+		}
+		e.buffer = encInt32(e.buffer, int32(len(node.Children)))
+		for _, child := range node.Children {
+			if err := e.encValueNode(child); err != nil {
+				return err
+			}
+		}
+
+	case KindMap:
+		if node.Type == "" {
+			e.buffer = encByte(e.buffer, BC_MAP_UNTYPED)
+		} else {
+			e.buffer = encByte(e.buffer, BC_MAP)
+			e.buffer = encString(e.buffer, node.Type)
+		}
+		for i, key := range node.Keys {
+			if err := e.encValueNode(key); err != nil {
+				return err
+			}
+			if err := e.encValueNode(node.Children[i]); err != nil {
+				return err
+			}
+		}
+		e.buffer = encByte(e.buffer, BC_END)
+
+	case KindObject:
+		idx := e.defineClass(&ClassInfo{javaName: node.Type, fieldNameList: node.Fields})
+		if cls := e.classInfoList[idx]; !equalStrings(cls.fieldNameList, node.Fields) {
+			return perrors.Errorf("the fields %v of class %s are different from the defined %v",
+				node.Fields, node.Type, cls.fieldNameList)
+		}
+		e.encObjectIndex(idx)
+		for _, child := range node.Children {
+			if err := e.encValueNode(child); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return perrors.Errorf("unknown value kind %s", node.Kind)
+	}
+	return nil
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHessianToJSON(t *testing.T) {
+	car := &disassembleCar{Name: "tesla", Wheels: []int32{1, 2}}
+	car.Owner = car
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(car))
+	assert.Nil(t, e.Encode(int64(7)))
+	assert.Nil(t, e.Encode(2.0))
+	assert.Nil(t, e.Encode(math.NaN()))
+	assert.Nil(t, e.Encode(time.Unix(1600000000, 0)))
+	assert.Nil(t, e.Encode([]byte{1, 2, 3}))
+	assert.Nil(t, e.Encode(map[interface{}]interface{}{int32(1): "a"}))
+	assert.Nil(t, e.Encode(map[interface{}]interface{}{"_k": nil}))
+	assert.Nil(t, e.Encode([]interface{}{"x", true}))
+	data := e.Buffer()
+
+	b, err := HessianToJSON(data, false)
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"_class":"example.Car","name":"tesla","wheels":{"_type":"[int","_list":[1,2]},"owner":{"_ref":0}},`+
+		`{"_long":7},2.0,{"_double":"NaN"},{"_date":"2020-09-13T12:26:40Z"},{"_binary":"AQID"},`+
+		`{"_entries":[[1,"a"]]},{"_entries":[["_k",null]]},["x",true]]`, string(b))
+
+	back, err := JSONToHessian(b)
+	assert.Nil(t, err)
+	assert.Equal(t, data, back)
+}
+
+func TestHessianToJSONResolveRefs(t *testing.T) {
+	list := []interface{}{"a"}
+	e := NewEncoder()
+	assert.Nil(t, e.Encode([]interface{}{list, list}))
+
+	b, err := HessianToJSON(e.Buffer(), false)
+	assert.Nil(t, err)
+	assert.Equal(t, `[[["a"],{"_ref":1}]]`, string(b))
+
+	b, err = HessianToJSON(e.Buffer(), true)
+	assert.Nil(t, err)
+	assert.Equal(t, `[[["a"],["a"]]]`, string(b))
+
+	car := &disassembleCar{Name: "tesla"}
+	car.Owner = car
+	e = NewEncoder()
+	assert.Nil(t, e.Encode(car))
+	b, err = HessianToJSON(e.Buffer(), true)
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"_class":"example.Car","name":"tesla","wheels":{"_type":"[int","_list":[]},"owner":{"_ref":0}}]`, string(b))
+}
+
+func TestJSONToHessian(t *testing.T) {
+	RegisterPOJO(&disassembleCar{})
+
+	data, err := JSONToHessian([]byte(`[
+		{"_class": "example.Car", "name": "bmw", "wheels": {"_type": "[int", "_list": [4]}, "owner": null},
+		{"_type": "java.util.HashMap", "_map": {"a": 3000000000}},
+		1.5
+	]`))
+	assert.Nil(t, err)
+
+	d := NewDecoder(data)
+	obj, err := d.Decode()
+	assert.Nil(t, err)
+	car, ok := obj.(*disassembleCar)
+	assert.True(t, ok)
+	assert.Equal(t, "bmw", car.Name)
+	assert.Equal(t, []int32{4}, car.Wheels)
+
+	m, err := d.Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, map[interface{}]interface{}{"a": int64(3000000000)}, m)
+
+	f, err := d.Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, 1.5, f)
+
+	_, err = JSONToHessian([]byte(`{"a": 1}`))
+	assert.NotNil(t, err)
+	_, err = JSONToHessian([]byte(`[{"_type": "x"}]`))
+	assert.NotNil(t, err)
+}
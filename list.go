@@ -149,6 +149,11 @@ func (e *Encoder) encList(v interface{}) error {
 // ::= 'V' type int value*   # fixed-length list
 // ::= [x70-77] type value*  # fixed-length typed list
 func (e *Encoder) writeTypedList(v interface{}) error {
+	return e.writeTypedListOf(v, "")
+}
+
+// writeTypedListOf writes the typed list of the java type, the type is got from the element type if it's empty.
+func (e *Encoder) writeTypedListOf(v interface{}, typeName string) error {
 	var err error
 
 	value := reflect.ValueOf(v)
@@ -160,11 +165,17 @@ func (e *Encoder) writeTypedList(v interface{}) error {
 	}
 
 	value = UnpackPtrValue(value)
-	goType := UnpackPtrType(value.Type().Elem())
-	totype := combineGoTypeName(goType)
-	typeName := getListTypeName(totype)
 	if typeName == "" {
-		return perrors.New("no this type name: " + totype)
+		goType := UnpackPtrType(value.Type().Elem())
+		totype := combineGoTypeName(goType)
+		typeName = getListTypeName(totype)
+		if typeName == "" && isStringEnumType(goType) {
+			// the java enum of go string type
+			typeName = "[" + reflect.Zero(goType).Interface().(POJO).JavaClassName()
+		}
+		if typeName == "" {
+			return perrors.New("no this type name: " + totype)
+		}
 	}
 
 	e.buffer = encByte(e.buffer, BC_LIST_FIXED) // 'V'
@@ -92,6 +92,11 @@ func getMapKey(key reflect.Value, t reflect.Type) (interface{}, error) {
 }
 
 func (e *Encoder) encMap(m interface{}) error {
+	return e.encTypedMap(m, "")
+}
+
+// encTypedMap encodes the map as the typed map of the java class, or as the untyped map if the type name is empty.
+func (e *Encoder) encTypedMap(m interface{}, typeName string) error {
 	var (
 		err   error
 		k     interface{}
@@ -124,12 +129,19 @@ func (e *Encoder) encMap(m interface{}) error {
 
 	keys = value.MapKeys()
 
-	e.buffer = encByte(e.buffer, BC_MAP_UNTYPED)
+	if typeName != "" {
+		e.buffer = encByte(e.buffer, BC_MAP)
+		e.buffer = encString(e.buffer, typeName)
+	} else {
+		e.buffer = encByte(e.buffer, BC_MAP_UNTYPED)
+	}
 	if len(keys) > 0 {
 		typ = value.Type().Key()
 		for i := 0; i < len(keys); i++ {
-			k, err = getMapKey(keys[i], typ)
-			if err != nil {
+			if isStringEnumType(typ) {
+				// the java enum of go string type
+				k = keys[i].Interface()
+			} else if k, err = getMapKey(keys[i], typ); err != nil {
 				return perrors.Wrapf(err, "getMapKey(idx:%d, key:%+v)", i, keys[i])
 			}
 			if err = e.Encode(k); err != nil {
@@ -177,6 +189,12 @@ func (d *Decoder) decMapByValue(value reflect.Value) error {
 		if decErr != nil {
 			return perrors.WithStack(decErr)
 		}
+		if mapObj, ok := refObj.(JavaMapObject); ok {
+			refObj = mapObj.Get()
+		}
+		if orderedMap, ok := refObj.(*OrderedMap); ok {
+			refObj = orderedMap.ToMap()
+		}
 		SetValue(value, EnsurePackValue(refObj))
 		return nil
 	case BC_MAP:
@@ -212,7 +230,15 @@ func (d *Decoder) decMapByValue(value reflect.Value) error {
 			return perrors.WithStack(err)
 		}
 
-		m.Elem().SetMapIndex(EnsurePackValue(entryKey), EnsureRawValue(entryValue))
+		mapKey, mapValue := EnsurePackValue(entryKey), EnsureRawValue(entryValue)
+		// the java enums of go string type
+		if k, ok := convertStringEnum(entryKey, m.Elem().Type().Key()); ok {
+			mapKey = k
+		}
+		if v, ok := convertStringEnum(entryValue, m.Elem().Type().Elem()); ok {
+			mapValue = v
+		}
+		m.Elem().SetMapIndex(mapKey, mapValue)
 	}
 
 	SetValue(value, m)
@@ -222,6 +248,11 @@ func (d *Decoder) decMapByValue(value reflect.Value) error {
 
 // decode map object
 func (d *Decoder) decMap(flag int32) (interface{}, error) {
+	return d.readMap(flag, d.UseOrderedMap)
+}
+
+// readMap decodes map object, the untyped map and the typed map of unregistered class are decoded to *OrderedMap if ordered is true.
+func (d *Decoder) readMap(flag int32, ordered bool) (interface{}, error) {
 	var (
 		err        error
 		tag        byte
@@ -251,6 +282,18 @@ func (d *Decoder) decMap(flag int32) (interface{}, error) {
 			return nil, err
 		}
 
+		if isMapObjectType(typ) {
+			return d.decodeMapObject(typ)
+		}
+
+		if typ == enumMapType {
+			return d.decodeEnumMap()
+		}
+
+		if ordered && typ.Kind() == reflect.Map && typ.Key().Kind() == reflect.Interface {
+			return d.decodeOrderedMapEntries()
+		}
+
 		if typ.Kind() == reflect.Map {
 			instValue = reflect.MakeMap(typ)
 		} else {
@@ -288,6 +331,10 @@ func (d *Decoder) decMap(flag int32) (interface{}, error) {
 		}
 		return instValue.Interface(), nil
 	case tag == BC_MAP_UNTYPED:
+		if ordered {
+			return d.decodeOrderedMapEntries()
+		}
+
 		m = make(map[interface{}]interface{})
 		d.appendRefs(m)
 		for d.peekByte() != BC_END {
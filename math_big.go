@@ -0,0 +1,240 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+)
+
+import (
+	gxbig "github.com/dubbogo/gost/math/big"
+	perrors "github.com/pkg/errors"
+)
+
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigRatType   = reflect.TypeOf(big.Rat{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+
+	// bigDecimalClassInfo is the class definition of java.math.BigDecimal, which is written as the string value.
+	bigDecimalClassInfo = &ClassInfo{javaName: "java.math.BigDecimal", fieldNameList: []string{"value"}}
+)
+
+// encBigInt encodes big.Int as java.math.BigInteger.
+func (e *Encoder) encBigInt(i *big.Int) error {
+	if i == nil {
+		e.buffer = EncNull(e.buffer)
+		return nil
+	}
+	v := &bigInteger{}
+	v.SetValue(i)
+	return IntegerSerializer{}.EncObject(e, v)
+}
+
+// encBigDecimal encodes the decimal string as java.math.BigDecimal. It's written directly rather than by gxbig.Decimal,
+// which overflows with more than 81 digits, while java.math.BigDecimal has no such limit.
+func (e *Encoder) encBigDecimal(s string) {
+	e.encObjectIndex(e.defineClass(bigDecimalClassInfo))
+	e.buffer = encString(e.buffer, s)
+}
+
+// encBigRat encodes big.Rat as java.math.BigDecimal, the scale is the least one to represent the value exactly,
+// so it fails if the value has no finite decimal representation, like 1/3.
+func (e *Encoder) encBigRat(r *big.Rat) error {
+	if r == nil {
+		e.buffer = EncNull(e.buffer)
+		return nil
+	}
+	scale, ok := decimalScale(r.Denom())
+	if !ok {
+		return perrors.Errorf("%s has no finite decimal representation", r.String())
+	}
+	e.encBigDecimal(r.FloatString(scale))
+	return nil
+}
+
+// encBigFloat encodes big.Float as java.math.BigDecimal of the shortest decimal which rounds to the value,
+// like the way float64 is formatted by strconv, in the plain notation without the exponent.
+func (e *Encoder) encBigFloat(f *big.Float) error {
+	if f == nil {
+		e.buffer = EncNull(e.buffer)
+		return nil
+	}
+	if f.IsInf() {
+		return perrors.New("infinite big.Float can not be encoded as BigDecimal")
+	}
+	e.encBigDecimal(f.Text('f', -1))
+	return nil
+}
+
+// encUint64 encodes uint64 as long, or as java.math.BigInteger if it overflows and Encoder.Uint64AsBigInteger is true.
+func (e *Encoder) encUint64(v uint64) error {
+	if v > math.MaxInt64 && e.Uint64AsBigInteger {
+		return e.encBigInt(new(big.Int).SetUint64(v))
+	}
+	e.buffer = encInt64(e.buffer, int64(v))
+	return nil
+}
+
+// decimalScale returns the least number of the decimal digits after the point of the fraction with the denominator,
+// it's false if the denominator has prime factors other than 2 and 5.
+func decimalScale(denom *big.Int) (int, bool) {
+	var (
+		d     = new(big.Int).Set(denom)
+		q     = new(big.Int)
+		r     = new(big.Int)
+		five  = big.NewInt(5)
+		twos  = int(d.TrailingZeroBits())
+		fives int
+	)
+	d.Rsh(d, uint(twos))
+	for {
+		q.QuoRem(d, five, r)
+		if r.Sign() != 0 {
+			break
+		}
+		d.Set(q)
+		fives++
+	}
+	if d.Cmp(big.NewInt(1)) != 0 {
+		return 0, false
+	}
+	if twos > fives {
+		return twos, true
+	}
+	return fives, true
+}
+
+// isMathBigType checks whether the type is big.Int, big.Rat or big.Float.
+func isMathBigType(typ reflect.Type) bool {
+	return typ == bigIntType || typ == bigRatType || typ == bigFloatType
+}
+
+// decBigDecimalField decodes the field of big.Rat or big.Float. The BigDecimal is parsed from the value string
+// rather than by gxbig.Decimal, which overflows with more than 81 digits, the other values are decoded by decObject.
+func (d *Decoder) decBigDecimalField() (interface{}, error) {
+	tag, err := d.ReadByte()
+	if err != nil {
+		return nil, perrors.WithStack(err)
+	}
+
+	var idx int32
+	switch {
+	case tag == BC_OBJECT_DEF:
+		clsDef, err := d.decClassDef()
+		if err != nil {
+			return nil, perrors.Wrap(err, "decBigDecimalField->decClassDef")
+		}
+		d.appendClsDef(clsDef.(*ClassInfo))
+		return d.decBigDecimalField()
+	case tag == BC_OBJECT:
+		if idx, err = d.decInt32(TAG_READ); err != nil {
+			return nil, err
+		}
+	case BC_OBJECT_DIRECT <= tag && tag <= (BC_OBJECT_DIRECT+OBJECT_DIRECT_MAX):
+		idx = int32(tag - BC_OBJECT_DIRECT)
+	default:
+		if err = d.unreadByte(); err != nil {
+			return nil, perrors.WithStack(err)
+		}
+		return d.decObject(TAG_READ)
+	}
+
+	if idx < 0 || int(idx) >= len(d.classInfoList) || d.classInfoList[idx].javaName != bigDecimalClassInfo.javaName {
+		return d.decObjectOfClass(int(idx))
+	}
+	cls := d.classInfoList[idx]
+
+	refIdx := len(d.refs)
+	d.appendRefs(nil)
+	var value interface{}
+	for _, fieldName := range cls.fieldNameList {
+		v, err := d.DecodeValue()
+		if err != nil {
+			return nil, perrors.Wrapf(err, "decBigDecimalField -> decode field name:%s", fieldName)
+		}
+		if fieldName == "value" {
+			value = v
+		}
+	}
+	s, ok := value.(string)
+	if !ok {
+		return nil, perrors.Errorf("invalid BigDecimal value %v", value)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, perrors.Errorf("invalid BigDecimal value %q", s)
+	}
+	d.refs[refIdx] = r
+	return r, nil
+}
+
+// toMathBig converts the decoded BigInteger and BigDecimal to the math/big type of the field.
+func toMathBig(typ reflect.Type, v interface{}) (interface{}, error) {
+	var r *big.Rat
+	switch n := v.(type) {
+	case nil:
+		return nil, nil
+	case *bigInteger:
+		if typ == bigIntType {
+			return new(big.Int).Set(n.Value()), nil
+		}
+		r = new(big.Rat).SetInt(n.Value())
+	case *big.Rat:
+		r = n
+	case *gxbig.Decimal:
+		var ok bool
+		if r, ok = new(big.Rat).SetString(n.Value); !ok {
+			return nil, perrors.Errorf("invalid BigDecimal value %q", n.Value)
+		}
+	default:
+		return nil, perrors.Errorf("can not convert %T to %s", v, typ)
+	}
+
+	switch typ {
+	case bigIntType:
+		if !r.IsInt() {
+			return nil, perrors.Errorf("%s is not an integer", r.RatString())
+		}
+		return new(big.Int).Set(r.Num()), nil
+	case bigRatType:
+		return r, nil
+	default:
+		// the precision is large enough for the numerator and denominator
+		return new(big.Float).SetRat(r), nil
+	}
+}
+
+// toUint64 converts the decoded long or BigInteger to uint64.
+func toUint64(v interface{}) (uint64, error) {
+	switch n := v.(type) {
+	case int32:
+		return uint64(n), nil
+	case int64:
+		return uint64(n), nil
+	case *bigInteger:
+		if !n.Value().IsUint64() {
+			return 0, perrors.Errorf("BigInteger %s overflows uint64", n.String())
+		}
+		return n.Value().Uint64(), nil
+	default:
+		return 0, perrors.Errorf("can not convert %T to uint64", v)
+	}
+}
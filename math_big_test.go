@@ -0,0 +1,153 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+import (
+	gxbig "github.com/dubbogo/gost/math/big"
+	"github.com/stretchr/testify/assert"
+)
+
+type mathBigHolder struct {
+	Count   *big.Int   `hessian:"count"`
+	Total   big.Int    `hessian:"total"`
+	Price   *big.Rat   `hessian:"price"`
+	Rate    *big.Float `hessian:"rate"`
+	Counter uint64     `hessian:"counter"`
+}
+
+func (mathBigHolder) JavaClassName() string {
+	return "test.model.MathBigHolder"
+}
+
+func TestMathBigEncode(t *testing.T) {
+	n, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(n))
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, n.String(), res.(*gxbig.Integer).String())
+
+	for _, c := range []struct {
+		value    interface{}
+		expected string
+	}{
+		{big.NewRat(1234, 100), "12.34"},
+		{big.NewRat(-3, 8), "-0.375"},
+		{big.NewRat(7, 1), "7"},
+		{big.NewFloat(0.1), "0.1"},
+		{big.NewFloat(1.5e20), "150000000000000000000"},
+	} {
+		e := NewEncoder()
+		assert.Nil(t, e.Encode(c.value))
+		res, err := NewDecoder(e.Buffer()).Decode()
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, res.(*gxbig.Decimal).String())
+	}
+
+	assert.NotNil(t, NewEncoder().Encode(big.NewRat(1, 3)))
+	assert.NotNil(t, NewEncoder().Encode(new(big.Float).SetInf(false)))
+}
+
+func TestMathBigEncodeLongDecimal(t *testing.T) {
+	digits := strings.Repeat("1234567890", 11)
+	num, _ := new(big.Int).SetString(digits, 10)
+	googol := strings.Repeat("0", 100)
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(new(big.Rat).SetFrac(num, big.NewInt(1000))))
+	assert.Nil(t, e.Encode(big.NewFloat(1e100)))
+	// the class definition is shared with gxbig.Decimal
+	decimal := &gxbig.Decimal{}
+	assert.Nil(t, decimal.FromString("1.5"))
+	assert.Nil(t, e.Encode(decimal))
+
+	nodes, err := ParseValueTree(e.Buffer())
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(nodes))
+	for _, node := range nodes {
+		assert.Equal(t, "java.math.BigDecimal", node.Type)
+		assert.Equal(t, []string{"value"}, node.Fields)
+	}
+	assert.Equal(t, digits[:107]+".89", nodes[0].Children[0].Value)
+	assert.Equal(t, "1"+googol, nodes[1].Children[0].Value)
+	assert.Equal(t, "1.5", nodes[2].Children[0].Value)
+	assert.Equal(t, 1, bytes.Count(e.Buffer(), []byte("java.math.BigDecimal")))
+
+	// the long values are decoded back into the fields
+	RegisterPOJO(&mathBigHolder{})
+	price := new(big.Rat).SetFrac(num, big.NewInt(1000))
+	rate, _ := new(big.Float).SetPrec(512).SetString("1" + googol + ".5")
+	e = NewEncoder()
+	assert.Nil(t, e.Encode(&mathBigHolder{Price: price, Rate: rate}))
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	h := res.(*mathBigHolder)
+	assert.Equal(t, price.RatString(), h.Price.RatString())
+	assert.Equal(t, "1"+googol+".5", h.Rate.Text('f', -1))
+}
+
+func TestMathBigField(t *testing.T) {
+	RegisterPOJO(&mathBigHolder{})
+
+	count, _ := new(big.Int).SetString("98765432109876543210", 10)
+	holder := &mathBigHolder{
+		Count:   count,
+		Total:   *big.NewInt(-42),
+		Price:   big.NewRat(1999, 100),
+		Rate:    big.NewFloat(0.25),
+		Counter: math.MaxUint64 - 1,
+	}
+
+	for _, asBigInteger := range []bool{false, true} {
+		e := NewEncoder()
+		e.Uint64AsBigInteger = asBigInteger
+		assert.Nil(t, e.Encode(holder))
+
+		res, err := NewDecoder(e.Buffer()).Decode()
+		assert.Nil(t, err)
+		h := res.(*mathBigHolder)
+		assert.Equal(t, 0, count.Cmp(h.Count))
+		assert.Equal(t, int64(-42), h.Total.Int64())
+		assert.Equal(t, "1999/100", h.Price.RatString())
+		assert.Equal(t, "0.25", h.Rate.Text('g', -1))
+		assert.Equal(t, holder.Counter, h.Counter)
+	}
+
+	e := NewEncoder()
+	e.Uint64AsBigInteger = true
+	assert.Nil(t, e.Encode(uint64(math.MaxUint64)))
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, "18446744073709551615", res.(*gxbig.Integer).String())
+
+	// the value in the range of long is still a long
+	e = NewEncoder()
+	e.Uint64AsBigInteger = true
+	assert.Nil(t, e.Encode(uint64(7)))
+	res, err = NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), res)
+}
@@ -0,0 +1,140 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// FieldNamingStrategy derives the hessian field name of a struct field which has no name in its tag.
+type FieldNamingStrategy func(field reflect.StructField) string
+
+var (
+	// LowerCamelCaseNaming lowers the first letter of the field name, e.g. URLPath => uRLPath.
+	// It's the default naming strategy.
+	LowerCamelCaseNaming FieldNamingStrategy = func(field reflect.StructField) string {
+		return lowerCamelCase(field.Name)
+	}
+
+	// JavaBeanNaming lowers the leading acronym of the field name like the java bean properties,
+	// e.g. URLPath => urlPath, ID => id, UserID => userID.
+	JavaBeanNaming FieldNamingStrategy = func(field reflect.StructField) string {
+		return javaBeanCase(field.Name)
+	}
+
+	// SnakeCaseNaming converts the field name to snake case, e.g. URLPath => url_path, UserID => user_id.
+	SnakeCaseNaming FieldNamingStrategy = func(field reflect.StructField) string {
+		return snakeCase(field.Name)
+	}
+)
+
+// JSONTagNaming uses the name in the json tag of the field,
+// and falls back to @fallback if the field has no json name.
+func JSONTagNaming(fallback FieldNamingStrategy) FieldNamingStrategy {
+	if fallback == nil {
+		fallback = LowerCamelCaseNaming
+	}
+	return func(field reflect.StructField) string {
+		name := strings.TrimSpace(strings.Split(field.Tag.Get("json"), ",")[0])
+		if name != "" && name != "-" {
+			return name
+		}
+		return fallback(field)
+	}
+}
+
+// SetFieldNamingStrategy sets the naming strategy of the POJOs registered after it,
+// the nil strategy resets it to LowerCamelCaseNaming.
+// The name in the hessian tag always takes precedence over the strategy.
+func SetFieldNamingStrategy(naming FieldNamingStrategy) {
+	pojoRegistry.Lock()
+	pojoRegistry.naming = naming
+	pojoRegistry.Unlock()
+
+	// the fields of the unregistered structs are matched by the default strategy
+	fieldIndexCache.Range(func(key, _ interface{}) bool {
+		fieldIndexCache.Delete(key)
+		return true
+	})
+}
+
+// fieldName returns the hessian field name of the struct field.
+func (s FieldNamingStrategy) fieldName(field reflect.StructField, tag fieldTag) string {
+	if tag.name != "" {
+		return tag.name
+	}
+	if s == nil {
+		return lowerCamelCase(field.Name)
+	}
+	return s(field)
+}
+
+// defaultNaming returns the naming strategy of the registry, it should be called with the registry lock.
+func (p *POJORegistry) defaultNaming() FieldNamingStrategy {
+	if p.naming == nil {
+		return LowerCamelCaseNaming
+	}
+	return p.naming
+}
+
+// fieldNamingOf returns the naming strategy which @typ was registered with.
+func fieldNamingOf(typ reflect.Type) FieldNamingStrategy {
+	pojoRegistry.RLock()
+	defer pojoRegistry.RUnlock()
+
+	if s, ok := pojoRegistry.registry[combineGoTypeName(typ)]; ok && s.naming != nil {
+		return s.naming
+	}
+	return pojoRegistry.defaultNaming()
+}
+
+// javaBeanCase lowers the leading upper case letters of @s,
+// except the last one which starts the next word.
+func javaBeanCase(s string) string {
+	runes := []rune(s)
+	n := 0
+	for n < len(runes) && unicode.IsUpper(runes[n]) {
+		n++
+	}
+	if n > 1 && n < len(runes) && unicode.IsLower(runes[n]) {
+		n--
+	}
+	for i := 0; i < n; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// snakeCase converts @s to lower case words separated by underscores.
+func snakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && runes[i-1] != '_' &&
+				(!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,149 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"reflect"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+type NamingBase struct {
+	HTTPServer string
+}
+
+type namingDTO struct {
+	NamingBase
+	URLPath   string
+	UserID    int32
+	ID        int64
+	Name      string `json:"display_name"`
+	Remark    string `hessian:"memo"`
+	Age2Years int32
+}
+
+func (namingDTO) JavaClassName() string {
+	return "test.naming.NamingDTO"
+}
+
+type snakeNamingDTO namingDTO
+
+func (snakeNamingDTO) JavaClassName() string {
+	return "test.naming.SnakeNamingDTO"
+}
+
+type jsonNamingDTO namingDTO
+
+func (jsonNamingDTO) JavaClassName() string {
+	return "test.naming.JSONNamingDTO"
+}
+
+func TestFieldNamingStrategyCase(t *testing.T) {
+	javaBean := map[string]string{
+		"URLPath":    "urlPath",
+		"ID":         "id",
+		"UserID":     "userID",
+		"Name":       "name",
+		"URL2":       "url2",
+		"X":          "x",
+		"HTTPServer": "httpServer",
+	}
+	for name, expected := range javaBean {
+		assert.Equal(t, expected, javaBeanCase(name))
+	}
+
+	snake := map[string]string{
+		"URLPath":     "url_path",
+		"ID":          "id",
+		"UserID":      "user_id",
+		"Name":        "name",
+		"Age2Years":   "age2_years",
+		"HTTPServer":  "http_server",
+		"Already_Set": "already_set",
+	}
+	for name, expected := range snake {
+		assert.Equal(t, expected, snakeCase(name))
+	}
+}
+
+func TestFieldNamingStrategy(t *testing.T) {
+	RegisterPOJOWithNaming(&namingDTO{}, JavaBeanNaming)
+	RegisterPOJOWithNaming(&snakeNamingDTO{}, SnakeCaseNaming)
+	RegisterPOJOWithNaming(&jsonNamingDTO{}, JSONTagNaming(SnakeCaseNaming))
+
+	testCases := []struct {
+		value  interface{}
+		fields []string
+	}{
+		{
+			value:  &namingDTO{NamingBase{"s"}, "/a", 1, 2, "n", "r", 3},
+			fields: []string{"urlPath", "userID", "id", "name", "memo", "age2Years", "httpServer"},
+		},
+		{
+			value:  &snakeNamingDTO{NamingBase{"s"}, "/a", 1, 2, "n", "r", 3},
+			fields: []string{"url_path", "user_id", "id", "name", "memo", "age2_years", "http_server"},
+		},
+		{
+			value:  &jsonNamingDTO{NamingBase{"s"}, "/a", 1, 2, "n", "r", 3},
+			fields: []string{"url_path", "user_id", "id", "display_name", "memo", "age2_years", "http_server"},
+		},
+	}
+
+	for _, tc := range testCases {
+		e := NewEncoder()
+		assert.Nil(t, e.Encode(tc.value))
+
+		nodes, err := ParseValueTree(e.Buffer())
+		assert.Nil(t, err)
+		assert.Equal(t, tc.fields, nodes[0].Fields)
+
+		res, err := NewDecoder(e.Buffer()).Decode()
+		assert.Nil(t, err)
+		assert.Equal(t, tc.value, res)
+	}
+}
+
+type defaultNamingDTO struct {
+	URLPath string
+}
+
+func (defaultNamingDTO) JavaClassName() string {
+	return "test.naming.DefaultNamingDTO"
+}
+
+func TestSetFieldNamingStrategy(t *testing.T) {
+	SetFieldNamingStrategy(SnakeCaseNaming)
+	defer SetFieldNamingStrategy(nil)
+
+	cls := pojoRegistry.classInfoList[RegisterPOJO(&defaultNamingDTO{})]
+	assert.Equal(t, []string{"url_path"}, cls.fieldNameList)
+
+	// the decoder matches the name of the strategy
+	idx, _, err := findFieldWithCache("url_path", reflect.TypeOf(defaultNamingDTO{}))
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0}, idx)
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(&defaultNamingDTO{URLPath: "/x"}))
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, &defaultNamingDTO{URLPath: "/x"}, res)
+}
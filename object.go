@@ -146,7 +146,9 @@ func (e *Encoder) encObject(v interface{}) error {
 			if reflect.TypeOf(v).Implements(javaEnumType) {
 				idx = RegisterJavaEnum(v.(POJOEnum))
 			} else if isPojo {
-				idx = RegisterPOJO(pojo)
+				if idx, err = TryRegisterPOJO(pojo); err != nil {
+					return perrors.WithStack(err)
+				}
 			} else {
 				return perrors.Errorf("non-pojo obj %s has not being registered before!", typeof(v))
 			}
@@ -187,7 +189,8 @@ func (e *Encoder) encObject(v interface{}) error {
 			}
 
 			// skip ignored field
-			if tag, _ := tf.Tag.Lookup(tagIdentifier); tag == `-` {
+			tag := parseFieldTag(tf)
+			if tag.ignored {
 				continue
 			}
 
@@ -197,7 +200,7 @@ func (e *Encoder) encObject(v interface{}) error {
 				continue
 			}
 
-			if err = e.Encode(field.Interface()); err != nil {
+			if err = e.encField(tag, field); err != nil {
 				fieldName := field.Type().String()
 				return perrors.Wrapf(err, "failed to encode field: %s, %+v", fieldName, field.Interface())
 			}
@@ -252,6 +255,11 @@ func (e *Encoder) EncodeMapAsClass(className string, m map[string]interface{}) e
 // Sometimes a class may not being registered in hessian, but it can be decoded from serialized data,
 // and the ClassInfo can be found in Decoder by calling Decoder.FindClassInfo.
 func (e *Encoder) EncodeMapAsObject(clsDef *ClassInfo, m map[string]interface{}) error {
+	return e.encodeMapAsIndexedClass(e.defineClass(clsDef), m)
+}
+
+// defineClass writes the class definition if it's not written yet, and returns the index of it in the encoder class list.
+func (e *Encoder) defineClass(clsDef *ClassInfo) int {
 	idx := e.classIndex(clsDef.javaName)
 	if idx == -1 {
 		idx = len(e.classInfoList)
@@ -261,18 +269,23 @@ func (e *Encoder) EncodeMapAsObject(clsDef *ClassInfo, m map[string]interface{})
 		}
 		e.buffer = append(e.buffer, clsDef.buffer...)
 	}
-	return e.encodeMapAsIndexedClass(idx, m)
+	return idx
 }
 
-// encodeMapAsIndexedClass encode a map as the defined class at the given index in the encoder class list.
-func (e *Encoder) encodeMapAsIndexedClass(idx int, m map[string]interface{}) error {
-	// write object instance
+// encObjectIndex writes the object instance tag of the defined class at the given index.
+func (e *Encoder) encObjectIndex(idx int) {
 	if byte(idx) <= OBJECT_DIRECT_MAX {
 		e.buffer = encByte(e.buffer, byte(idx)+BC_OBJECT_DIRECT)
 	} else {
 		e.buffer = encByte(e.buffer, BC_OBJECT)
 		e.buffer = encInt32(e.buffer, int32(idx))
 	}
+}
+
+// encodeMapAsIndexedClass encode a map as the defined class at the given index in the encoder class list.
+func (e *Encoder) encodeMapAsIndexedClass(idx int, m map[string]interface{}) error {
+	// write object instance
+	e.encObjectIndex(idx)
 
 	cls := e.classInfoList[idx]
 	var err error
@@ -408,7 +421,7 @@ func findFieldWithCache(name string, typ reflect.Type) ([]int, *reflect.StructFi
 		return finfo.indexes, finfo.field, err
 	}
 
-	indexes, field, err := findField(name, typ)
+	indexes, field, err := findField(name, typ, fieldNamingOf(typ))
 	typCache.(*sync.Map).Store(name, &fieldInfo{indexes: indexes, field: field})
 	return indexes, field, err
 }
@@ -419,16 +432,17 @@ func findFieldWithCache(name string, typ reflect.Type) ([]int, *reflect.StructFi
 // 	indexes []int
 // 	field reflect.StructField
 // 	err error
-func findField(name string, typ reflect.Type) ([]int, *reflect.StructField, error) {
+func findField(name string, typ reflect.Type, naming FieldNamingStrategy) ([]int, *reflect.StructField, error) {
 	for i := 0; i < typ.NumField(); i++ {
-		// matching tag first, then lowerCamelCase, SameCase, lowerCase
+		// matching tag first, then the naming strategy, tag aliases, SameCase, lowerCamelCase, lowerCase
 
 		typField := typ.Field(i)
 
-		tagVal, hasTag := typField.Tag.Lookup(tagIdentifier)
+		tag := parseFieldTag(typField)
 
 		fieldName := typField.Name
-		if hasTag && tagVal == name ||
+		if naming.fieldName(typField, tag) == name ||
+			tag.hasAlias(name) ||
 			fieldName == name ||
 			lowerCamelCase(fieldName) == name ||
 			strings.ToLower(fieldName) == name {
@@ -437,7 +451,7 @@ func findField(name string, typ reflect.Type) ([]int, *reflect.StructField, erro
 		}
 
 		if typField.Anonymous && typField.Type.Kind() == reflect.Struct {
-			next, field, _ := findField(name, typField.Type)
+			next, field, _ := findField(name, typField.Type, naming)
 			if len(next) > 0 {
 				indexes := []int{i}
 				indexes = append(indexes, next...)
@@ -486,8 +500,39 @@ func (d *Decoder) decInstance(typ reflect.Type, cls *ClassInfo) (interface{}, er
 		fldRawValue := UnpackPtrValue(field)
 		kind := fldTyp.Kind()
 
+		if isJavaCharField(*fieldStruct) {
+			// the char is written as a string
+			s, err := d.Decode()
+			if err != nil {
+				return nil, perrors.Wrapf(err, "decInstance->Decode field name:%s", fieldName)
+			}
+			c, err := toJavaChar(s)
+			if err != nil {
+				return nil, perrors.Wrapf(err, "decInstance field name:%s", fieldName)
+			}
+			if s != nil {
+				SetValue(fldRawValue, reflect.ValueOf(c))
+			}
+			continue
+		}
+
 		switch kind {
 		case reflect.String:
+			if isStringEnumType(fldTyp) {
+				s, err := d.Decode()
+				if err != nil {
+					return nil, perrors.Wrapf(err, "decInstance->Decode field name:%s", fieldName)
+				}
+				if s == nil {
+					break
+				}
+				enumValue, ok := convertStringEnum(s, fldTyp)
+				if !ok {
+					return nil, perrors.Errorf("can not convert %T to enum %s, field name:%s", s, fldTyp, fieldName)
+				}
+				SetValue(fldRawValue, enumValue)
+				break
+			}
 			str, err := d.decString(TAG_READ)
 			if err != nil {
 				return nil, perrors.Wrapf(err, "decInstance->ReadString: %s", fieldName)
@@ -497,15 +542,16 @@ func (d *Decoder) decInstance(typ reflect.Type, cls *ClassInfo) (interface{}, er
 		case reflect.Int32, reflect.Int16, reflect.Int8:
 			num, err := d.decInt32(TAG_READ)
 			if err != nil {
-				// java enum
-				if fldRawValue.Type().Implements(javaEnumType) {
+				// java enum, or the enum of java8 time like java8_time.DayOfWeek
+				if fldRawValue.Type().Implements(javaEnumType) || fldTyp.Implements(pojoType) {
 					d.unreadByte() // Enum parsing, decInt64 above has read a byte, so you need to return a byte here
 					s, decErr := d.DecodeValue()
 					if decErr != nil {
 						return nil, perrors.Wrapf(decErr, "decInstance->decObject field name:%s", fieldName)
 					}
-					enumValue, _ := s.(JavaEnum)
-					num = int32(enumValue)
+					if enumValue := reflect.ValueOf(s); enumValue.Kind() == reflect.Int32 {
+						num = int32(enumValue.Int())
+					}
 				} else {
 					return nil, perrors.Wrapf(err, "decInstance->decInt32, field name:%s", fieldName)
 				}
@@ -518,6 +564,14 @@ func (d *Decoder) decInstance(typ reflect.Type, cls *ClassInfo) (interface{}, er
 			}
 			fldRawValue.SetUint(uint64(num))
 		case reflect.Uint, reflect.Int, reflect.Int64:
+			if fldTyp == durationType {
+				duration, err := d.decDurationField()
+				if err != nil {
+					return nil, perrors.Wrapf(err, "decInstance->decDurationField field name:%s", fieldName)
+				}
+				fldRawValue.SetInt(int64(duration))
+				break
+			}
 			num, err := d.decInt64(TAG_READ)
 			if err != nil {
 				if fldTyp.Implements(javaEnumType) {
@@ -537,7 +591,21 @@ func (d *Decoder) decInstance(typ reflect.Type, cls *ClassInfo) (interface{}, er
 		case reflect.Uint32, reflect.Uint64:
 			num, err := d.decInt64(TAG_READ)
 			if err != nil {
-				return nil, perrors.Wrapf(err, "decInstance->decInt64, field name:%s", fieldName)
+				if kind != reflect.Uint64 {
+					return nil, perrors.Wrapf(err, "decInstance->decInt64, field name:%s", fieldName)
+				}
+				// the uint64 overflowing long is sent as BigInteger
+				d.unreadByte()
+				s, decErr := d.Decode()
+				if decErr != nil {
+					return nil, perrors.Wrapf(decErr, "decInstance->Decode field name:%s", fieldName)
+				}
+				u, convErr := toUint64(s)
+				if convErr != nil {
+					return nil, perrors.Wrapf(convErr, "decInstance field name:%s", fieldName)
+				}
+				fldRawValue.SetUint(u)
+				break
 			}
 			fldRawValue.SetUint(uint64(num))
 		case reflect.Bool:
@@ -573,6 +641,21 @@ func (d *Decoder) decInstance(typ reflect.Type, cls *ClassInfo) (interface{}, er
 			}
 
 		case reflect.Slice, reflect.Array:
+			if fldTyp == javaCharSliceType {
+				// char[] is written as a string
+				s, err := d.Decode()
+				if err != nil {
+					return nil, perrors.Wrapf(err, "decInstance->Decode field name:%s", fieldName)
+				}
+				chars, err := toJavaChars(s)
+				if err != nil {
+					return nil, perrors.Wrapf(err, "decInstance field name:%s", fieldName)
+				}
+				if chars != nil {
+					SetValue(fldRawValue, reflect.ValueOf(chars))
+				}
+				break
+			}
 			m, err := d.decList(TAG_READ)
 			if err != nil {
 				if perrors.Is(err, io.EOF) {
@@ -592,17 +675,47 @@ func (d *Decoder) decInstance(typ reflect.Type, cls *ClassInfo) (interface{}, er
 				s   interface{}
 			)
 			fldType := UnpackPtrType(fldRawValue.Type())
-			if fldType.String() == "time.Time" {
-				s, err = d.decDate(TAG_READ)
+			if fldType == timeType {
+				s, err = d.decTimeField()
 				if err != nil {
 					return nil, perrors.WithStack(err)
 				}
 				SetValue(fldRawValue, EnsurePackValue(s))
+			} else if fldType == orderedMapType {
+				s, err = d.readMap(TAG_READ, true)
+				if err != nil {
+					return nil, perrors.WithStack(err)
+				}
+				if m, ok := s.(*OrderedMap); ok {
+					SetValue(fldRawValue, EnsurePackValue(m))
+				}
+			} else if isMapObjectType(fldType) || fldType == enumMapType {
+				s, err = d.decMapObjectField(fldType)
+				if err != nil {
+					return nil, perrors.Wrapf(err, "decInstance field name:%s", fieldName)
+				}
+				if s != nil {
+					SetValue(fldRawValue, EnsurePackValue(s))
+				}
 			} else {
-				s, err = d.decObject(TAG_READ)
+				if tag := d.peekByte(); tag == BC_MAP || tag == BC_MAP_UNTYPED {
+					return nil, perrors.Errorf("can not decode map into field %s of struct type %s", fieldName, fldType)
+				}
+				if fldType == bigRatType || fldType == bigFloatType {
+					s, err = d.decBigDecimalField()
+				} else {
+					s, err = d.decObject(TAG_READ)
+				}
 				if err != nil {
 					return nil, perrors.WithStack(err)
 				}
+				if fldType == java8ZoneIdType {
+					s = toJava8ZoneId(s)
+				} else if isMathBigType(fldType) {
+					if s, err = toMathBig(fldType, s); err != nil {
+						return nil, perrors.Wrapf(err, "decInstance field name:%s", fieldName)
+					}
+				}
 				if s != nil {
 					// set value which accepting pointers
 					SetValue(fldRawValue, EnsurePackValue(s))
@@ -651,7 +764,7 @@ func (d *Decoder) getStructDefByIndex(idx int) (reflect.Type, *ClassInfo, error)
 		if s, ok = checkAndGetException(cls); ok {
 			return s.typ, cls, nil
 		}
-		if !d.isSkip && d.Strict {
+		if !d.isSkip && d.Strict && !isRegisteredJavaEnumValues(cls.javaName) {
 			err = perrors.Errorf("can not find go type name %s in registry", cls.javaName)
 		}
 		return nil, cls, err
@@ -678,6 +791,9 @@ func (d *Decoder) decEnum(javaName string, flag int32) (JavaEnum, error) {
 	}
 
 	enumValue = info.inst.(POJOEnum).EnumValue(enumName)
+	if enumValue == InvalidJavaEnum && d.DisallowUnknownEnum {
+		return InvalidJavaEnum, perrors.Errorf("unknown name %s of enum %s", enumName, javaName)
+	}
 	d.appendRefs(enumValue)
 	return enumValue, nil
 }
@@ -704,7 +820,6 @@ func (d *Decoder) decObject(flag int32) (interface{}, error) {
 		tag byte
 		idx int32
 		err error
-		typ reflect.Type
 		cls *ClassInfo
 	)
 
@@ -735,51 +850,40 @@ func (d *Decoder) decObject(flag int32) (interface{}, error) {
 		if err != nil {
 			return nil, err
 		}
+		return d.decObjectOfClass(int(idx))
 
-		typ, cls, err = d.getStructDefByIndex(int(idx))
-		if err != nil {
-			return nil, err
-		}
-		if typ == nil {
-			if d.isSkip {
-				return nil, d.skip(cls)
-			}
-			return d.decClassToMap(cls)
-		}
-		if typ.Implements(javaEnumType) {
-			return d.decEnum(cls.javaName, TAG_READ)
-		}
-
-		if c, ok := GetSerializer(cls.javaName); ok {
-			return c.DecObject(d, typ, cls)
-		}
+	case BC_OBJECT_DIRECT <= tag && tag <= (BC_OBJECT_DIRECT+OBJECT_DIRECT_MAX):
+		return d.decObjectOfClass(int(tag - BC_OBJECT_DIRECT))
 
-		return d.decInstance(typ, cls)
+	default:
+		return nil, perrors.Errorf("decObject illegal object type tag:%+v", tag)
+	}
+}
 
-	case BC_OBJECT_DIRECT <= tag && tag <= (BC_OBJECT_DIRECT+OBJECT_DIRECT_MAX):
-		typ, cls, err = d.getStructDefByIndex(int(tag - BC_OBJECT_DIRECT))
-		if err != nil {
-			return nil, err
-		}
-		if typ == nil {
-			if d.isSkip {
-				return nil, d.skip(cls)
-			}
-			return d.decClassToMap(cls)
+// decObjectOfClass decodes the object of the class definition at the index.
+func (d *Decoder) decObjectOfClass(idx int) (interface{}, error) {
+	typ, cls, err := d.getStructDefByIndex(idx)
+	if err != nil {
+		return nil, err
+	}
+	if typ == nil {
+		if d.isSkip {
+			return nil, d.skip(cls)
 		}
-		if typ.Implements(javaEnumType) {
-			return d.decEnum(cls.javaName, TAG_READ)
+		if d.isJavaEnumValueClass(cls) {
+			return d.decJavaEnumValue(cls)
 		}
+		return d.decClassToMap(cls)
+	}
+	if typ.Implements(javaEnumType) {
+		return d.decEnum(cls.javaName, TAG_READ)
+	}
 
-		if c, ok := GetSerializer(cls.javaName); ok {
-			return c.DecObject(d, typ, cls)
-		}
-
-		return d.decInstance(typ, cls)
-
-	default:
-		return nil, perrors.Errorf("decObject illegal object type tag:%+v", tag)
+	if c, ok := GetSerializer(cls.javaName); ok {
+		return c.DecObject(d, typ, cls)
 	}
+
+	return d.decInstance(typ, cls)
 }
 
 func (d *Decoder) decClassToMap(cls *ClassInfo) (interface{}, error) {
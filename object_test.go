@@ -1061,3 +1061,52 @@ func TestDecodeSliceIntegerHasNull(t *testing.T) {
 	RegisterPOJO(&User{})
 	testDecodeFramework(t, "customReplyTypedListIntegerHasNull", &User{Id: 0, List: []int32{1, 0}})
 }
+
+type aliasAccount struct {
+	AccountID string `hessian:"accountId,alias=acctId|accountID"`
+	Balance   int64  `hessian:",alias=amount"`
+}
+
+func (aliasAccount) JavaClassName() string {
+	return "test.alias.Account"
+}
+
+type aliasAccountV1 struct {
+	AcctID string `hessian:"acctId"`
+	Amount int64
+}
+
+func (aliasAccountV1) JavaClassName() string {
+	return "test.alias.Account"
+}
+
+func TestDecodeFieldAlias(t *testing.T) {
+	encodeAs := func(o POJO) []byte {
+		RegisterPOJO(o)
+		defer UnRegisterPOJOs(o)
+
+		e := NewEncoder()
+		assert.Nil(t, e.Encode(o))
+		return e.Buffer()
+	}
+
+	old := encodeAs(&aliasAccountV1{AcctID: "a1", Amount: 10})
+
+	RegisterPOJO(&aliasAccount{})
+	defer UnRegisterPOJOs(&aliasAccount{})
+
+	// the old names are accepted by the aliases
+	res, err := NewDecoder(old).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, &aliasAccount{AccountID: "a1", Balance: 10}, res)
+
+	// the primary name is encoded
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(&aliasAccount{AccountID: "a1", Balance: 10}))
+	nodes, err := ParseValueTree(e.Buffer())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"accountId", "balance"}, nodes[0].Fields)
+	res, err = NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, &aliasAccount{AccountID: "a1", Balance: 10}, res)
+}
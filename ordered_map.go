@@ -0,0 +1,155 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"reflect"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+// OrderedMap is a map which keeps the insertion order of the keys, like java.util.LinkedHashMap.
+// It's encoded as an untyped map with the entries in order, and the decoder produces it for
+// the maps when Decoder.UseOrderedMap is true.
+type OrderedMap struct {
+	keys   []interface{}
+	values map[interface{}]interface{}
+}
+
+var orderedMapType = reflect.TypeOf(OrderedMap{})
+
+// NewOrderedMap creates an empty ordered map.
+func NewOrderedMap(capacity int) *OrderedMap {
+	return &OrderedMap{
+		keys:   make([]interface{}, 0, capacity),
+		values: make(map[interface{}]interface{}, capacity),
+	}
+}
+
+// Put sets the value of the key, a new key is appended to the end, and an existing key keeps its position.
+func (m *OrderedMap) Put(key, value interface{}) {
+	if m.values == nil {
+		m.values = make(map[interface{}]interface{})
+	}
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Get returns the value of the key, and whether the key exists.
+func (m *OrderedMap) Get(key interface{}) (interface{}, bool) {
+	value, ok := m.values[key]
+	return value, ok
+}
+
+// Delete removes the key.
+func (m *OrderedMap) Delete(key interface{}) {
+	if _, ok := m.values[key]; !ok {
+		return
+	}
+	delete(m.values, key)
+	for i, k := range m.keys {
+		if k == key {
+			m.keys = append(m.keys[:i], m.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// Len returns the number of entries.
+func (m *OrderedMap) Len() int {
+	return len(m.keys)
+}
+
+// Keys returns the keys in order.
+func (m *OrderedMap) Keys() []interface{} {
+	keys := make([]interface{}, len(m.keys))
+	copy(keys, m.keys)
+	return keys
+}
+
+// Range calls f for each entry in order, it stops if f returns false.
+func (m *OrderedMap) Range(f func(key, value interface{}) bool) {
+	for _, k := range m.keys {
+		if !f(k, m.values[k]) {
+			return
+		}
+	}
+}
+
+// ToMap returns the entries as an unordered go map.
+func (m *OrderedMap) ToMap() map[interface{}]interface{} {
+	res := make(map[interface{}]interface{}, len(m.keys))
+	for k, v := range m.values {
+		res[k] = v
+	}
+	return res
+}
+
+// ::= 'H' (value value)* 'Z'       # untyped key, value
+func (e *Encoder) encOrderedMap(m *OrderedMap) error {
+	if m == nil {
+		e.buffer = EncNull(e.buffer)
+		return nil
+	}
+
+	// check ref
+	if n, ok := e.checkRefMap(reflect.ValueOf(m)); ok {
+		e.buffer = encRef(e.buffer, n)
+		return nil
+	}
+
+	var err error
+	e.buffer = encByte(e.buffer, BC_MAP_UNTYPED)
+	for _, k := range m.keys {
+		if err = e.Encode(k); err != nil {
+			return err
+		}
+		if err = e.Encode(m.values[k]); err != nil {
+			return err
+		}
+	}
+	e.buffer = encByte(e.buffer, BC_END) // 'Z'
+
+	return nil
+}
+
+// decodeOrderedMapEntries decodes the map entries into *OrderedMap, the map tag and type have been read.
+func (d *Decoder) decodeOrderedMapEntries() (*OrderedMap, error) {
+	m := NewOrderedMap(8)
+	d.appendRefs(m)
+
+	for d.peekByte() != BC_END {
+		k, err := d.Decode()
+		if err != nil {
+			return nil, err
+		}
+		v, err := d.Decode()
+		if err != nil {
+			return nil, err
+		}
+		m.Put(k, v)
+	}
+	if _, err := d.ReadByte(); err != nil {
+		return nil, perrors.WithStack(err)
+	}
+	return m, nil
+}
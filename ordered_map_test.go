@@ -0,0 +1,115 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+type OrderedMapHolder struct {
+	Props *OrderedMap
+}
+
+func (OrderedMapHolder) JavaClassName() string {
+	return "test.model.OrderedMapHolder"
+}
+
+func TestOrderedMap(t *testing.T) {
+	m := NewOrderedMap(4)
+	m.Put("c", 1)
+	m.Put("a", 2)
+	m.Put("b", 3)
+	m.Put("a", 4)
+	assert.Equal(t, []interface{}{"c", "a", "b"}, m.Keys())
+
+	v, ok := m.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 4, v)
+
+	m.Delete("c")
+	m.Delete("x")
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, []interface{}{"a", "b"}, m.Keys())
+	assert.Equal(t, map[interface{}]interface{}{"a": 4, "b": 3}, m.ToMap())
+}
+
+func TestOrderedMapEncodeDecode(t *testing.T) {
+	m := NewOrderedMap(16)
+	for _, k := range []string{"z", "y", "x", "w", "v", "u", "t", "s"} {
+		m.Put(k, int32(len(k)))
+	}
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(m))
+	data := e.Buffer()
+	assert.Equal(t, byte(BC_MAP_UNTYPED), data[0])
+	assert.Equal(t, []byte{0x01, 'z', 0x91, 0x01, 'y'}, data[1:6])
+
+	// decoded as go map by default
+	res, err := NewDecoder(data).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, m.ToMap(), res)
+
+	d := NewDecoder(data)
+	d.UseOrderedMap = true
+	res, err = d.Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, m.Keys(), res.(*OrderedMap).Keys())
+
+	// encode again in the same order
+	e = NewEncoder()
+	assert.Nil(t, e.Encode(res))
+	assert.Equal(t, data, e.Buffer())
+}
+
+func TestOrderedMapDecodeTyped(t *testing.T) {
+	e := NewEncoder()
+	e.buffer = encByte(e.buffer, BC_MAP)
+	e.buffer = encString(e.buffer, "java.util.LinkedHashMap")
+	for _, k := range []string{"b", "a", "c"} {
+		e.buffer = encString(e.buffer, k)
+		e.buffer = encString(e.buffer, k)
+	}
+	e.buffer = encByte(e.buffer, BC_END)
+
+	d := NewDecoder(e.Buffer())
+	d.UseOrderedMap = true
+	res, err := d.Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"b", "a", "c"}, res.(*OrderedMap).Keys())
+}
+
+func TestOrderedMapField(t *testing.T) {
+	RegisterPOJO(&OrderedMapHolder{})
+
+	m := NewOrderedMap(2)
+	m.Put("k2", "v2")
+	m.Put("k1", "v1")
+
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(&OrderedMapHolder{Props: m}))
+
+	// the field of OrderedMap type is always decoded in order
+	res, err := NewDecoder(e.Buffer()).Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"k2", "k1"}, res.(*OrderedMapHolder).Props.Keys())
+}
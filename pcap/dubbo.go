@@ -0,0 +1,294 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package pcap decodes dubbo requests and responses from tcpdump captures.
+// Both pcap and pcapng files are supported, tcp streams are reassembled
+// and split into dubbo frames, whose hessian2 bodies are decoded without
+// requiring any POJO registration.
+package pcap
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	hessian "github.com/apache/dubbo-go-hessian2"
+)
+
+// Message is a dubbo request or response captured in a tcp stream.
+type Message struct {
+	Time   time.Time
+	Src    string // ip:port of the sender
+	Dst    string // ip:port of the receiver
+	Header hessian.DubboHeader
+
+	// request part
+	DubboVersion string
+	Service      string // the service path
+	Version      string
+	Method       string
+	ArgTypes     string // the java descriptor of the arguments, like "Ljava/lang/String;I"
+	Args         []interface{}
+
+	// response part
+	Result    interface{}
+	Exception error
+
+	Attachments map[string]string
+
+	// Err is set when the body of the message can not be decoded.
+	Err error
+}
+
+// IsRequest returns true if the message is a request.
+func (m *Message) IsRequest() bool {
+	return m.Header.Type&hessian.PackageRequest != 0
+}
+
+// IsTwoWay returns true if the message is a request expecting a response.
+func (m *Message) IsTwoWay() bool {
+	return m.Header.Type&hessian.PackageRequest_TwoWay != 0
+}
+
+// IsHeartbeat returns true if the message is a heartbeat event.
+func (m *Message) IsHeartbeat() bool {
+	return m.Header.Type&hessian.PackageHeartbeat != 0
+}
+
+// Reader reads dubbo messages from a capture.
+type Reader struct {
+	source  packetSource
+	streams map[string]*stream
+	queue   []*Message
+}
+
+// NewReader creates a reader of a pcap or pcapng capture.
+func NewReader(r io.Reader) (*Reader, error) {
+	source, err := newPacketSource(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reader{
+		source:  source,
+		streams: make(map[string]*stream),
+	}, nil
+}
+
+// Next returns the next dubbo message in the order they are completed in the capture,
+// io.EOF is returned when there are no more messages.
+func (r *Reader) Next() (*Message, error) {
+	for len(r.queue) == 0 {
+		p, err := r.source.next()
+		if err != nil {
+			return nil, err
+		}
+
+		seg := decodeSegment(p)
+		if seg == nil {
+			continue
+		}
+
+		key := seg.src + ">" + seg.dst
+		s, ok := r.streams[key]
+		if !ok {
+			s = newStream(seg.src, seg.dst)
+			r.streams[key] = s
+		}
+		if s.add(seg) {
+			r.queue = append(r.queue, s.messages(seg.ts)...)
+		}
+		if seg.flags&(tcpFlagFin|tcpFlagRst) != 0 {
+			delete(r.streams, key)
+		}
+	}
+
+	m := r.queue[0]
+	r.queue = r.queue[1:]
+	return m, nil
+}
+
+// Read decodes all dubbo messages of a capture.
+func Read(r io.Reader) ([]*Message, error) {
+	reader, err := NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []*Message
+	for {
+		m, err := reader.Next()
+		if err != nil {
+			if perrors.Is(err, io.EOF) {
+				return messages, nil
+			}
+			return messages, err
+		}
+		messages = append(messages, m)
+	}
+}
+
+// ReadFile decodes all dubbo messages of a capture file.
+func ReadFile(name string) ([]*Message, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, perrors.WithStack(err)
+	}
+	defer f.Close()
+
+	return Read(f)
+}
+
+// messages splits the stream buffer into dubbo frames and decodes them.
+func (s *stream) messages(ts time.Time) []*Message {
+	var messages []*Message
+	for {
+		start, length := nextFrame(s.buffer)
+		if start < 0 {
+			// keep the last byte which may be the beginning of the magic code
+			if len(s.buffer) > 1 {
+				s.buffer = append(s.buffer[:0], s.buffer[len(s.buffer)-1:]...)
+			}
+			return messages
+		}
+		if length == 0 {
+			s.buffer = append(s.buffer[:0], s.buffer[start:]...)
+			return messages
+		}
+
+		m := DecodeFrame(s.buffer[start : start+length])
+		m.Time, m.Src, m.Dst = ts, s.src, s.dst
+		messages = append(messages, m)
+		s.buffer = append(s.buffer[:0], s.buffer[start+length:]...)
+	}
+}
+
+// nextFrame finds the first dubbo frame in buf, and returns its offset and total length.
+// The offset is -1 if no frame header is found, and the length is 0 if the frame is incomplete.
+func nextFrame(buf []byte) (int, int) {
+	for i := 0; i+1 < len(buf); i++ {
+		if buf[i] != hessian.MAGIC_HIGH || buf[i+1] != hessian.MAGIC_LOW {
+			continue
+		}
+		if len(buf)-i < hessian.HEADER_LENGTH {
+			return i, 0
+		}
+
+		header := buf[i : i+hessian.HEADER_LENGTH]
+		bodyLen := binary.BigEndian.Uint32(header[12:16])
+		if header[2]&hessian.SERIAL_MASK == hessian.Zero || bodyLen > hessian.DEFAULT_LEN {
+			// not a valid header, go on searching
+			continue
+		}
+
+		total := hessian.HEADER_LENGTH + int(bodyLen)
+		if len(buf)-i < total {
+			return i, 0
+		}
+		return i, total
+	}
+
+	return -1, 0
+}
+
+// DecodeFrame decodes a whole dubbo frame, including the 16 bytes header.
+// Classes in the body are decoded into maps if they are not registered.
+func DecodeFrame(frame []byte) *Message {
+	m := &Message{}
+
+	codec := hessian.NewHessianCodec(bufio.NewReaderSize(bytes.NewReader(frame), len(frame)))
+	if err := codec.ReadHeader(&m.Header); err != nil {
+		m.Err = err
+		return m
+	}
+
+	body := frame[hessian.HEADER_LENGTH:]
+	switch {
+	case m.IsHeartbeat():
+		// the body of heartbeat is null
+
+	case m.IsRequest():
+		m.Err = m.decodeRequest(hessian.NewDecoder(body))
+
+	case m.Header.ResponseStatus != hessian.Response_OK:
+		// the body is an error message
+		msg, err := hessian.NewDecoder(body).Decode()
+		if err != nil {
+			m.Err = perrors.WithStack(err)
+			break
+		}
+		m.Exception = perrors.Errorf("response status %d: %v", m.Header.ResponseStatus, msg)
+
+	default:
+		rsp := &hessian.Response{}
+		if err := codec.ReadBody(rsp); err != nil {
+			m.Err = err
+			break
+		}
+		m.Result, m.Exception, m.Attachments = rsp.RspObj, rsp.Exception, rsp.Attachments
+	}
+
+	return m
+}
+
+// decodeRequest decodes the request body:
+// dubbo version, path, version, method, argument types, arguments..., attachments
+func (m *Message) decodeRequest(d *hessian.Decoder) error {
+	var fields [5]string
+	for i := range fields {
+		v, err := d.Decode()
+		if err != nil {
+			return perrors.WithStack(err)
+		}
+		if v != nil {
+			s, ok := v.(string)
+			if !ok {
+				return perrors.Errorf("expect string request field, but get %T", v)
+			}
+			fields[i] = s
+		}
+	}
+	m.DubboVersion, m.Service, m.Version, m.Method, m.ArgTypes = fields[0], fields[1], fields[2], fields[3], fields[4]
+
+	types := hessian.DescRegex.FindAllString(m.ArgTypes, -1)
+	for i := range types {
+		arg, err := d.Decode()
+		if err != nil {
+			return perrors.Wrapf(err, "decode argument %d of type %s", i, types[i])
+		}
+		m.Args = append(m.Args, arg)
+	}
+
+	attachments, err := d.Decode()
+	if err != nil {
+		return perrors.WithStack(err)
+	}
+	if v, ok := attachments.(map[interface{}]interface{}); ok {
+		m.Attachments = hessian.ToMapStringString(v)
+	}
+
+	return nil
+}
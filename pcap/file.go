@@ -0,0 +1,323 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pcap
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+// link layer header types, see http://www.tcpdump.org/linktypes.html
+const (
+	linkTypeNull     = 0
+	linkTypeEthernet = 1
+	linkTypeRaw      = 101
+	linkTypeLoop     = 108
+	linkTypeLinuxSLL = 113
+	linkTypeIPv4     = 228
+	linkTypeIPv6     = 229
+	linkTypeSLL2     = 276
+	// some BSDs use 12 for raw ip
+	linkTypeRawBSD = 12
+)
+
+const (
+	pcapMagicMicros        = 0xa1b2c3d4
+	pcapMagicNanos         = 0xa1b23c4d
+	pcapngSectionHeader    = 0x0a0d0d0a
+	pcapngByteOrderMagic   = 0x1a2b3c4d
+	pcapngInterfaceDesc    = 0x00000001
+	pcapngPacketObsolete   = 0x00000002
+	pcapngSimplePacket     = 0x00000003
+	pcapngEnhancedPacket   = 0x00000006
+	pcapngOptionEnd        = 0
+	pcapngOptionTsResol    = 9
+	pcapMaxPacketSize      = 256 * 1024
+	pcapngMaxBlockSize     = 16 * 1024 * 1024
+	pcapGlobalHeaderLength = 24
+	pcapRecordHeaderLength = 16
+)
+
+// ErrUnknownFormat is returned when the input is neither a pcap nor a pcapng file.
+var ErrUnknownFormat = perrors.New("unknown capture file format")
+
+// packet is a captured link layer frame.
+type packet struct {
+	ts       time.Time
+	linkType int
+	data     []byte
+}
+
+// packetSource reads captured packets one by one, io.EOF is returned at the end of the capture.
+type packetSource interface {
+	next() (*packet, error)
+}
+
+// newPacketSource detects the capture format by its magic number.
+func newPacketSource(r io.Reader) (packetSource, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(4)
+	if err != nil {
+		return nil, perrors.WithStack(err)
+	}
+
+	if binary.LittleEndian.Uint32(magic) == pcapngSectionHeader {
+		return &pcapngReader{reader: br, order: binary.LittleEndian}, nil
+	}
+
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		switch order.Uint32(magic) {
+		case pcapMagicMicros, pcapMagicNanos:
+			return newPcapReader(br, order)
+		}
+	}
+
+	return nil, ErrUnknownFormat
+}
+
+/////////////////////////////////////////
+// pcap
+/////////////////////////////////////////
+
+// pcapReader reads the classic libpcap format:
+// global header ::= magic(4) version(2+2) zone(4) sigfigs(4) snaplen(4) linktype(4)
+// record       ::= ts_sec(4) ts_frac(4) incl_len(4) orig_len(4) data
+type pcapReader struct {
+	reader   *bufio.Reader
+	order    binary.ByteOrder
+	nanos    bool
+	linkType int
+}
+
+func newPcapReader(r *bufio.Reader, order binary.ByteOrder) (*pcapReader, error) {
+	var header [pcapGlobalHeaderLength]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, perrors.Wrap(err, "read pcap global header")
+	}
+
+	return &pcapReader{
+		reader:   r,
+		order:    order,
+		nanos:    order.Uint32(header[0:4]) == pcapMagicNanos,
+		linkType: int(order.Uint32(header[20:24]) & 0x0fffffff),
+	}, nil
+}
+
+func (p *pcapReader) next() (*packet, error) {
+	var header [pcapRecordHeaderLength]byte
+	if _, err := io.ReadFull(p.reader, header[:]); err != nil {
+		if perrors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+
+	sec := int64(p.order.Uint32(header[0:4]))
+	frac := int64(p.order.Uint32(header[4:8]))
+	inclLen := p.order.Uint32(header[8:12])
+	if inclLen > pcapMaxPacketSize {
+		return nil, perrors.Errorf("illegal pcap record length %d", inclLen)
+	}
+
+	data := make([]byte, inclLen)
+	if _, err := io.ReadFull(p.reader, data); err != nil {
+		return nil, perrors.Wrap(err, "read pcap record")
+	}
+
+	if !p.nanos {
+		frac *= int64(time.Microsecond)
+	}
+
+	return &packet{ts: time.Unix(sec, frac), linkType: p.linkType, data: data}, nil
+}
+
+/////////////////////////////////////////
+// pcapng
+/////////////////////////////////////////
+
+type pcapngInterface struct {
+	linkType int
+	// unit is the nanoseconds of a timestamp unit, 0 means the divisor should be used.
+	unit int64
+	// divisor is the timestamp units per second.
+	divisor int64
+}
+
+// pcapngReader reads the pcapng format, which is a sequence of blocks:
+// block ::= type(4) total_len(4) body total_len(4)
+type pcapngReader struct {
+	reader     *bufio.Reader
+	order      binary.ByteOrder
+	interfaces []pcapngInterface
+}
+
+func (p *pcapngReader) next() (*packet, error) {
+	for {
+		typ, body, err := p.readBlock()
+		if err != nil {
+			return nil, err
+		}
+
+		switch typ {
+		case pcapngSectionHeader:
+			// the interface ids are scoped in a section
+			p.interfaces = p.interfaces[:0]
+
+		case pcapngInterfaceDesc:
+			if len(body) < 8 {
+				return nil, perrors.New("illegal pcapng interface description block")
+			}
+			p.interfaces = append(p.interfaces, p.parseInterface(body))
+
+		case pcapngEnhancedPacket, pcapngPacketObsolete:
+			if len(body) < 20 {
+				return nil, perrors.New("illegal pcapng packet block")
+			}
+			var ifID uint32
+			if typ == pcapngEnhancedPacket {
+				ifID = p.order.Uint32(body[0:4])
+			} else {
+				ifID = uint32(p.order.Uint16(body[0:2]))
+			}
+			if int(ifID) >= len(p.interfaces) {
+				return nil, perrors.Errorf("pcapng packet refers to unknown interface %d", ifID)
+			}
+			capLen := p.order.Uint32(body[12:16])
+			if int(capLen) > len(body)-20 {
+				return nil, perrors.Errorf("illegal pcapng packet length %d", capLen)
+			}
+			ts := uint64(p.order.Uint32(body[4:8]))<<32 | uint64(p.order.Uint32(body[8:12]))
+			iface := p.interfaces[ifID]
+			return &packet{ts: iface.time(ts), linkType: iface.linkType, data: body[20 : 20+capLen]}, nil
+
+		case pcapngSimplePacket:
+			if len(p.interfaces) == 0 || len(body) < 4 {
+				return nil, perrors.New("illegal pcapng simple packet block")
+			}
+			origLen := int(p.order.Uint32(body[0:4]))
+			data := body[4:]
+			if origLen < len(data) {
+				data = data[:origLen]
+			}
+			return &packet{linkType: p.interfaces[0].linkType, data: data}, nil
+		}
+		// other blocks like name resolution and statistics are skipped.
+	}
+}
+
+// readBlock reads a whole block and returns its type and body.
+func (p *pcapngReader) readBlock() (uint32, []byte, error) {
+	var header [8]byte
+	if _, err := io.ReadFull(p.reader, header[:]); err != nil {
+		if perrors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, nil, io.EOF
+		}
+		return 0, nil, err
+	}
+
+	typ := p.order.Uint32(header[0:4])
+	if typ == pcapngSectionHeader {
+		// the byte order of a section is decided by the byte order magic of its header block
+		magic, err := p.reader.Peek(4)
+		if err != nil {
+			return 0, nil, perrors.Wrap(err, "read pcapng section header")
+		}
+		switch uint32(pcapngByteOrderMagic) {
+		case binary.LittleEndian.Uint32(magic):
+			p.order = binary.LittleEndian
+		case binary.BigEndian.Uint32(magic):
+			p.order = binary.BigEndian
+		default:
+			return 0, nil, ErrUnknownFormat
+		}
+	}
+
+	total := p.order.Uint32(header[4:8])
+	if total < 12 || total%4 != 0 || total > pcapngMaxBlockSize {
+		return 0, nil, perrors.Errorf("illegal pcapng block length %d", total)
+	}
+
+	buf := make([]byte, total-8)
+	if _, err := io.ReadFull(p.reader, buf); err != nil {
+		return 0, nil, perrors.Wrap(err, "read pcapng block")
+	}
+
+	return typ, buf[:len(buf)-4], nil
+}
+
+func (p *pcapngReader) parseInterface(body []byte) pcapngInterface {
+	iface := pcapngInterface{
+		linkType: int(p.order.Uint16(body[0:2])),
+		unit:     int64(time.Microsecond),
+	}
+
+	options := body[8:]
+	for len(options) >= 4 {
+		code := p.order.Uint16(options[0:2])
+		length := int(p.order.Uint16(options[2:4]))
+		if code == pcapngOptionEnd || 4+length > len(options) {
+			break
+		}
+		if code == pcapngOptionTsResol && length >= 1 {
+			iface.unit, iface.divisor = tsResolution(options[4])
+		}
+		options = options[4+(length+3)/4*4:]
+	}
+
+	return iface
+}
+
+// tsResolution parses the if_tsresol option, the high bit indicates a power of 2, otherwise a power of 10.
+// It returns the nanoseconds of a unit, or the units per second when a unit is shorter than a nanosecond.
+func tsResolution(v byte) (int64, int64) {
+	exp := int(v & 0x7f)
+	if v&0x80 != 0 {
+		if exp > 62 {
+			exp = 62
+		}
+		return 0, 1 << uint(exp)
+	}
+
+	if exp > 18 {
+		exp = 18
+	}
+	pow := int64(1)
+	for i := 0; i < exp; i++ {
+		pow *= 10
+	}
+	if exp <= 9 {
+		return int64(time.Second) / pow, 0
+	}
+	return 0, pow
+}
+
+func (i pcapngInterface) time(ts uint64) time.Time {
+	if i.unit == 0 {
+		sec := ts / uint64(i.divisor)
+		rem := float64(ts%uint64(i.divisor)) / float64(i.divisor)
+		return time.Unix(int64(sec), int64(rem*float64(time.Second)))
+	}
+	unitsPerSecond := uint64(int64(time.Second) / i.unit)
+	return time.Unix(int64(ts/unitsPerSecond), int64(ts%unitsPerSecond)*i.unit)
+}
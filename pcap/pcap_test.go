@@ -0,0 +1,251 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pcap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+import (
+	hessian "github.com/apache/dubbo-go-hessian2"
+)
+
+var (
+	clientAddr = []byte{10, 0, 0, 1}
+	serverAddr = []byte{10, 0, 0, 2}
+)
+
+// tcpPacket builds an ethernet frame carrying an ipv4 tcp packet.
+func tcpPacket(src, dst []byte, srcPort, dstPort uint16, seq uint32, flags byte, payload []byte) []byte {
+	tcp := make([]byte, 20)
+	binary.BigEndian.PutUint16(tcp[0:2], srcPort)
+	binary.BigEndian.PutUint16(tcp[2:4], dstPort)
+	binary.BigEndian.PutUint32(tcp[4:8], seq)
+	tcp[12] = 5 << 4
+	tcp[13] = flags | 0x10
+
+	ip := make([]byte, 20)
+	ip[0] = 0x45
+	binary.BigEndian.PutUint16(ip[2:4], uint16(20+len(tcp)+len(payload)))
+	ip[8] = 64
+	ip[9] = ipProtocolTCP
+	copy(ip[12:16], src)
+	copy(ip[16:20], dst)
+
+	eth := make([]byte, 14)
+	binary.BigEndian.PutUint16(eth[12:14], etherTypeIPv4)
+
+	frame := append(eth, ip...)
+	frame = append(frame, tcp...)
+	return append(frame, payload...)
+}
+
+func writePcap(packets [][]byte) []byte {
+	buf := &bytes.Buffer{}
+	header := make([]byte, pcapGlobalHeaderLength)
+	binary.LittleEndian.PutUint32(header[0:4], pcapMagicMicros)
+	binary.LittleEndian.PutUint16(header[4:6], 2)
+	binary.LittleEndian.PutUint16(header[6:8], 4)
+	binary.LittleEndian.PutUint32(header[16:20], 65535)
+	binary.LittleEndian.PutUint32(header[20:24], linkTypeEthernet)
+	buf.Write(header)
+
+	for i, p := range packets {
+		record := make([]byte, pcapRecordHeaderLength)
+		binary.LittleEndian.PutUint32(record[0:4], uint32(1600000000+i))
+		binary.LittleEndian.PutUint32(record[8:12], uint32(len(p)))
+		binary.LittleEndian.PutUint32(record[12:16], uint32(len(p)))
+		buf.Write(record)
+		buf.Write(p)
+	}
+	return buf.Bytes()
+}
+
+func writePcapng(packets [][]byte) []byte {
+	buf := &bytes.Buffer{}
+	block := func(typ uint32, body []byte) {
+		for len(body)%4 != 0 {
+			body = append(body, 0)
+		}
+		var b [4]byte
+		binary.LittleEndian.PutUint32(b[:], typ)
+		buf.Write(b[:])
+		binary.LittleEndian.PutUint32(b[:], uint32(len(body)+12))
+		buf.Write(b[:])
+		buf.Write(body)
+		buf.Write(b[:])
+	}
+
+	shb := make([]byte, 16)
+	binary.LittleEndian.PutUint32(shb[0:4], pcapngByteOrderMagic)
+	binary.LittleEndian.PutUint16(shb[4:6], 1)
+	binary.LittleEndian.PutUint64(shb[8:16], ^uint64(0))
+	block(pcapngSectionHeader, shb)
+
+	// interface with nanosecond resolution
+	idb := make([]byte, 8, 20)
+	binary.LittleEndian.PutUint16(idb[0:2], linkTypeEthernet)
+	idb = append(idb, pcapngOptionTsResol, 0, 1, 0, 9, 0, 0, 0, 0, 0, 0, 0)
+	block(pcapngInterfaceDesc, idb)
+
+	for i, p := range packets {
+		epb := make([]byte, 20)
+		ts := uint64(1600000000+i) * uint64(time.Second)
+		binary.LittleEndian.PutUint32(epb[4:8], uint32(ts>>32))
+		binary.LittleEndian.PutUint32(epb[8:12], uint32(ts))
+		binary.LittleEndian.PutUint32(epb[12:16], uint32(len(p)))
+		binary.LittleEndian.PutUint32(epb[16:20], uint32(len(p)))
+		block(pcapngEnhancedPacket, append(epb, p...))
+	}
+	return buf.Bytes()
+}
+
+func dubboConversation(t *testing.T) [][]byte {
+	codec := hessian.NewHessianCodec(nil)
+	req, err := codec.Write(hessian.Service{
+		Path:      "com.test.UserService",
+		Interface: "com.test.UserService",
+		Version:   "1.0.0",
+		Method:    "getUser",
+	}, hessian.DubboHeader{
+		SerialID: 2,
+		Type:     hessian.PackageRequest_TwoWay,
+		ID:       7,
+	}, hessian.NewRequest([]interface{}{"u001", int32(18)}, nil))
+	assert.Nil(t, err)
+
+	rsp, err := codec.Write(hessian.Service{}, hessian.DubboHeader{
+		SerialID:       2,
+		Type:           hessian.PackageResponse,
+		ID:             7,
+		ResponseStatus: hessian.Response_OK,
+	}, hessian.NewResponse(map[string]interface{}{"name": "tom"}, nil, map[string]string{"dubbo": "2.0.2"}))
+	assert.Nil(t, err)
+
+	half := len(req) / 2
+	const clientISN, serverISN = 1000, 5000
+	return [][]byte{
+		tcpPacket(clientAddr, serverAddr, 52100, 20880, clientISN, tcpFlagSyn, nil),
+		tcpPacket(serverAddr, clientAddr, 20880, 52100, serverISN, tcpFlagSyn, nil),
+		// the second half arrives before the first half
+		tcpPacket(clientAddr, serverAddr, 52100, 20880, clientISN+1+uint32(half), 0, req[half:]),
+		tcpPacket(clientAddr, serverAddr, 52100, 20880, clientISN+1, 0, req[:half]),
+		// retransmission
+		tcpPacket(clientAddr, serverAddr, 52100, 20880, clientISN+1, 0, req[:half]),
+		tcpPacket(serverAddr, clientAddr, 20880, 52100, serverISN+1, 0, rsp),
+	}
+}
+
+func assertConversation(t *testing.T, messages []*Message) {
+	if !assert.Equal(t, 2, len(messages)) {
+		return
+	}
+
+	req := messages[0]
+	assert.Nil(t, req.Err)
+	assert.True(t, req.IsRequest())
+	assert.Equal(t, "10.0.0.1:52100", req.Src)
+	assert.Equal(t, "10.0.0.2:20880", req.Dst)
+	assert.Equal(t, int64(7), req.Header.ID)
+	assert.Equal(t, "com.test.UserService", req.Service)
+	assert.Equal(t, "1.0.0", req.Version)
+	assert.Equal(t, "getUser", req.Method)
+	assert.Equal(t, "Ljava/lang/String;I", req.ArgTypes)
+	assert.Equal(t, []interface{}{"u001", int32(18)}, req.Args)
+	assert.Equal(t, "com.test.UserService", req.Attachments["interface"])
+	assert.Equal(t, int64(1600000003), req.Time.Unix())
+
+	rsp := messages[1]
+	assert.Nil(t, rsp.Err)
+	assert.False(t, rsp.IsRequest())
+	assert.Equal(t, int64(7), rsp.Header.ID)
+	assert.Nil(t, rsp.Exception)
+	assert.Equal(t, map[interface{}]interface{}{"name": "tom"}, rsp.Result)
+	assert.Equal(t, "2.0.2", rsp.Attachments["dubbo"])
+}
+
+func TestReadPcap(t *testing.T) {
+	messages, err := Read(bytes.NewReader(writePcap(dubboConversation(t))))
+	assert.Nil(t, err)
+	assertConversation(t, messages)
+}
+
+func TestReadPcapng(t *testing.T) {
+	messages, err := Read(bytes.NewReader(writePcapng(dubboConversation(t))))
+	assert.Nil(t, err)
+	assertConversation(t, messages)
+}
+
+func TestReadUnknownFormat(t *testing.T) {
+	_, err := Read(bytes.NewReader([]byte("not a capture file")))
+	assert.Equal(t, ErrUnknownFormat, err)
+}
+
+func TestNextFrame(t *testing.T) {
+	codec := hessian.NewHessianCodec(nil)
+	frame, err := codec.Write(hessian.Service{}, hessian.DubboHeader{
+		SerialID:       2,
+		Type:           hessian.PackageResponse,
+		ResponseStatus: hessian.Response_OK,
+	}, hessian.NewResponse("ok", nil, nil))
+	assert.Nil(t, err)
+
+	// garbage before the frame
+	buf := append([]byte{0x01, hessian.MAGIC_HIGH, 0x02}, frame...)
+	start, length := nextFrame(buf)
+	assert.Equal(t, 3, start)
+	assert.Equal(t, len(frame), length)
+
+	// incomplete frame
+	start, length = nextFrame(buf[:len(buf)-1])
+	assert.Equal(t, 3, start)
+	assert.Equal(t, 0, length)
+
+	start, _ = nextFrame([]byte{0x01, 0x02})
+	assert.Equal(t, -1, start)
+}
+
+func TestStreamSkipGap(t *testing.T) {
+	s := newStream("client", "server")
+	assert.False(t, s.add(&segment{seq: 0, flags: tcpFlagSyn}))
+
+	// the segment at 1 is lost, and the one at 511 arrives late
+	const n = maxPendingSegments + 100
+	var expected []byte
+	for i := 0; i < n; i++ {
+		expected = append(expected, byte(i))
+		if i == 500 {
+			continue
+		}
+		s.add(&segment{seq: uint32(11 + i), payload: []byte{byte(i)}})
+	}
+	// the gap is skipped and the run before the late one is appended
+	assert.Equal(t, expected[:500], s.buffer)
+	assert.Equal(t, n-501, len(s.pending))
+
+	assert.True(t, s.add(&segment{seq: 511, payload: expected[500:501]}))
+	assert.Equal(t, expected, s.buffer)
+	assert.Equal(t, 0, len(s.pending))
+}
@@ -0,0 +1,282 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pcap
+
+import (
+	"encoding/binary"
+	"net"
+	"strconv"
+	"time"
+)
+
+const (
+	etherTypeIPv4 = 0x0800
+	etherTypeIPv6 = 0x86dd
+	etherTypeVLAN = 0x8100
+	etherTypeQinQ = 0x88a8
+
+	ipProtocolTCP = 6
+
+	tcpFlagFin = 0x01
+	tcpFlagSyn = 0x02
+	tcpFlagRst = 0x04
+
+	// maxPendingSegments limits the out of order segments buffered for a stream.
+	maxPendingSegments = 1024
+)
+
+// segment is the payload of a tcp packet.
+type segment struct {
+	ts      time.Time
+	src     string
+	dst     string
+	seq     uint32
+	flags   byte
+	payload []byte
+}
+
+// decodeSegment parses the link layer, ip layer and tcp layer of a packet.
+// It returns nil if the packet is not a tcp packet.
+func decodeSegment(p *packet) *segment {
+	data := p.data
+	var etherType uint16
+
+	switch p.linkType {
+	case linkTypeEthernet:
+		if len(data) < 14 {
+			return nil
+		}
+		etherType = binary.BigEndian.Uint16(data[12:14])
+		data = data[14:]
+		for (etherType == etherTypeVLAN || etherType == etherTypeQinQ) && len(data) >= 4 {
+			etherType = binary.BigEndian.Uint16(data[2:4])
+			data = data[4:]
+		}
+	case linkTypeNull, linkTypeLoop:
+		if len(data) < 4 {
+			return nil
+		}
+		// the family is in host byte order for NULL, and in network byte order for LOOP
+		family := binary.LittleEndian.Uint32(data[0:4])
+		if p.linkType == linkTypeLoop || family > 0xffff {
+			family = binary.BigEndian.Uint32(data[0:4])
+		}
+		data = data[4:]
+		if family == 2 {
+			etherType = etherTypeIPv4
+		} else {
+			// AF_INET6 differs between platforms: 10, 24, 28, 30
+			etherType = etherTypeIPv6
+		}
+	case linkTypeLinuxSLL:
+		if len(data) < 16 {
+			return nil
+		}
+		etherType = binary.BigEndian.Uint16(data[14:16])
+		data = data[16:]
+	case linkTypeSLL2:
+		if len(data) < 20 {
+			return nil
+		}
+		etherType = binary.BigEndian.Uint16(data[0:2])
+		data = data[20:]
+	case linkTypeRaw, linkTypeRawBSD, linkTypeIPv4, linkTypeIPv6:
+		if len(data) < 1 {
+			return nil
+		}
+		if data[0]>>4 == 6 {
+			etherType = etherTypeIPv6
+		} else {
+			etherType = etherTypeIPv4
+		}
+	default:
+		return nil
+	}
+
+	var (
+		srcIP, dstIP net.IP
+		protocol     byte
+	)
+
+	switch etherType {
+	case etherTypeIPv4:
+		if len(data) < 20 || data[0]>>4 != 4 {
+			return nil
+		}
+		ihl := int(data[0]&0x0f) * 4
+		total := int(binary.BigEndian.Uint16(data[2:4]))
+		// fragmented packets are not supported
+		if ihl < 20 || len(data) < ihl || binary.BigEndian.Uint16(data[6:8])&0x3fff != 0 {
+			return nil
+		}
+		if total >= ihl && total < len(data) {
+			// trim the ethernet padding
+			data = data[:total]
+		}
+		protocol = data[9]
+		srcIP, dstIP = net.IP(data[12:16]), net.IP(data[16:20])
+		data = data[ihl:]
+	case etherTypeIPv6:
+		if len(data) < 40 || data[0]>>4 != 6 {
+			return nil
+		}
+		payloadLen := int(binary.BigEndian.Uint16(data[4:6]))
+		protocol = data[6]
+		srcIP, dstIP = net.IP(data[8:24]), net.IP(data[24:40])
+		data = data[40:]
+		if payloadLen < len(data) {
+			data = data[:payloadLen]
+		}
+		// skip the hop-by-hop, routing and destination options extension headers
+		for (protocol == 0 || protocol == 43 || protocol == 60) && len(data) >= 8 {
+			extLen := (int(data[1]) + 1) * 8
+			if extLen > len(data) {
+				return nil
+			}
+			protocol = data[0]
+			data = data[extLen:]
+		}
+	default:
+		return nil
+	}
+
+	if protocol != ipProtocolTCP || len(data) < 20 {
+		return nil
+	}
+
+	offset := int(data[12]>>4) * 4
+	if offset < 20 || offset > len(data) {
+		return nil
+	}
+
+	return &segment{
+		ts:      p.ts,
+		src:     net.JoinHostPort(srcIP.String(), strconv.Itoa(int(binary.BigEndian.Uint16(data[0:2])))),
+		dst:     net.JoinHostPort(dstIP.String(), strconv.Itoa(int(binary.BigEndian.Uint16(data[2:4])))),
+		seq:     binary.BigEndian.Uint32(data[4:8]),
+		flags:   data[13],
+		payload: data[offset:],
+	}
+}
+
+// stream reassembles the tcp payload of one direction of a connection.
+type stream struct {
+	src     string
+	dst     string
+	started bool
+	nextSeq uint32
+	pending map[uint32]*segment
+	buffer  []byte
+}
+
+func newStream(src, dst string) *stream {
+	return &stream{src: src, dst: dst, pending: make(map[uint32]*segment)}
+}
+
+// add appends the segment payload to the stream, out of order segments are
+// buffered until the missing data arrives. It returns true if the stream buffer grows.
+func (s *stream) add(seg *segment) bool {
+	if seg.flags&tcpFlagSyn != 0 {
+		s.started = true
+		s.nextSeq = seg.seq + 1
+		s.buffer = s.buffer[:0]
+		s.pending = make(map[uint32]*segment)
+		return false
+	}
+
+	if len(seg.payload) == 0 {
+		return false
+	}
+
+	// the capture may begin in the middle of a connection
+	if !s.started {
+		s.started = true
+		s.nextSeq = seg.seq
+	}
+
+	grown := false
+	if seqDiff(seg.seq, s.nextSeq) > 0 && len(s.pending) >= maxPendingSegments {
+		// too many lost packets, skip the gap
+		grown = s.skipGap()
+	}
+
+	if seqDiff(seg.seq, s.nextSeq) > 0 {
+		s.pending[seg.seq] = seg
+		return grown
+	}
+
+	if s.appendSegment(seg) {
+		grown = true
+	}
+	if s.appendPending() {
+		grown = true
+	}
+	return grown
+}
+
+// appendPending appends the buffered segments which follow the next expected sequence.
+func (s *stream) appendPending() bool {
+	grown := false
+	for len(s.pending) > 0 {
+		progress := false
+		for seq, p := range s.pending {
+			if seqDiff(seq, s.nextSeq) <= 0 {
+				delete(s.pending, seq)
+				if s.appendSegment(p) {
+					grown = true
+				}
+				progress = true
+			}
+		}
+		if !progress {
+			break
+		}
+	}
+	return grown
+}
+
+// appendSegment appends the part of payload which is after the next expected sequence.
+func (s *stream) appendSegment(seg *segment) bool {
+	overlap := -seqDiff(seg.seq, s.nextSeq)
+	if overlap >= len(seg.payload) {
+		// retransmission
+		return false
+	}
+	s.buffer = append(s.buffer, seg.payload[overlap:]...)
+	s.nextSeq = seg.seq + uint32(len(seg.payload))
+	return true
+}
+
+// skipGap drops the data which can not be completed, and moves the next expected sequence to the earliest
+// buffered segment, then the run of buffered segments from it is appended and removed from the pending ones.
+func (s *stream) skipGap() bool {
+	first := true
+	for seq := range s.pending {
+		if first || seqDiff(seq, s.nextSeq) < 0 {
+			s.nextSeq = seq
+			first = false
+		}
+	}
+	s.buffer = s.buffer[:0]
+	return s.appendPending()
+}
+
+// seqDiff returns a-b considering the wraparound of tcp sequence numbers.
+func seqDiff(a, b uint32) int {
+	return int(int32(a - b))
+}
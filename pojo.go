@@ -86,6 +86,7 @@ type structInfo struct {
 	javaName string
 	index    int // classInfoList index
 	inst     interface{}
+	naming   FieldNamingStrategy
 }
 
 // POJORegistry pojo registry struct
@@ -94,6 +95,7 @@ type POJORegistry struct {
 	classInfoList []*ClassInfo           // {class name, field name list...} list
 	j2g           map[string]string      // java class name --> go struct name
 	registry      map[string]*structInfo // go class name --> go struct info
+	naming        FieldNamingStrategy    // default field naming strategy
 }
 
 var (
@@ -133,8 +135,36 @@ func RegisterPOJO(o POJO) int {
 	return RegisterPOJOMapping(o.JavaClassName(), o)
 }
 
+// RegisterPOJOWithNaming Register a POJO instance whose field names are derived by @naming.
+func RegisterPOJOWithNaming(o POJO, naming FieldNamingStrategy) int {
+	return RegisterPOJOMappingWithNaming(o.JavaClassName(), o, naming)
+}
+
 // RegisterPOJOMapping Register a POJO instance. The return value is -1 if @o has been registered.
 func RegisterPOJOMapping(javaClassName string, o interface{}) int {
+	return RegisterPOJOMappingWithNaming(javaClassName, o, nil)
+}
+
+// RegisterPOJOMappingWithNaming Register a POJO instance whose field names are derived by @naming,
+// the nil @naming means the strategy set by SetFieldNamingStrategy.
+// The return value is -1 if @o is invalid, use TryRegisterPOJOMapping to get the error.
+func RegisterPOJOMappingWithNaming(javaClassName string, o interface{}, naming FieldNamingStrategy) int {
+	idx, err := TryRegisterPOJOMapping(javaClassName, o, naming)
+	if err != nil {
+		return -1
+	}
+	return idx
+}
+
+// TryRegisterPOJO Register a POJO instance, it returns the error if @o is invalid, like the type option of a field tag
+// doesn't match the field.
+func TryRegisterPOJO(o POJO) (int, error) {
+	return TryRegisterPOJOMapping(o.JavaClassName(), o, nil)
+}
+
+// TryRegisterPOJOMapping Register a POJO instance whose field names are derived by @naming,
+// it returns the error if @o is invalid.
+func TryRegisterPOJOMapping(javaClassName string, o interface{}, naming FieldNamingStrategy) (int, error) {
 	// # definition for an object (compact map)
 	// class-def  ::= 'C' string int string*
 	pojoRegistry.Lock()
@@ -142,12 +172,22 @@ func RegisterPOJOMapping(javaClassName string, o interface{}) int {
 
 	if goName, ok := pojoRegistry.j2g[javaClassName]; ok {
 		// TODO print warning message about duplicate registration JavaClass
-		return pojoRegistry.registry[goName].index
+		return pojoRegistry.registry[goName].index, nil
 	}
 
 	// JavaClassName shouldn't equal to goName
 	if _, ok := pojoRegistry.registry[javaClassName]; ok {
-		return -1
+		return -1, perrors.Errorf("java class name %s is the same as a registered go type name", javaClassName)
+	}
+
+	// the go string enums are written as java enums without registration
+	if isStringEnumType(obtainValueType(o)) {
+		return -1, perrors.Errorf("%s is a string enum of %s, which can't be registered as a POJO", GetGoType(o), javaClassName)
+	}
+
+	// the java types in the tags should match the fields
+	if err := checkFieldTypes(obtainValueType(o)); err != nil {
+		return -1, err
 	}
 
 	var (
@@ -162,6 +202,10 @@ func RegisterPOJOMapping(javaClassName string, o interface{}) int {
 	sttInfo.goName = GetGoType(o)
 	sttInfo.javaName = javaClassName
 	sttInfo.inst = o
+	if naming == nil {
+		naming = pojoRegistry.defaultNaming()
+	}
+	sttInfo.naming = naming
 	pojoRegistry.j2g[sttInfo.javaName] = sttInfo.goName
 	registerTypeName(sttInfo.goName, sttInfo.javaName)
 
@@ -179,8 +223,8 @@ func RegisterPOJOMapping(javaClassName string, o interface{}) int {
 				structField := current.Field(i)
 
 				// skip ignored field
-				tagVal, hasTag := structField.Tag.Lookup(tagIdentifier)
-				if tagVal == `-` {
+				tag := parseFieldTag(structField)
+				if tag.ignored {
 					continue
 				}
 
@@ -190,13 +234,7 @@ func RegisterPOJOMapping(javaClassName string, o interface{}) int {
 					continue
 				}
 
-				var fieldName string
-				if hasTag {
-					fieldName = tagVal
-				} else {
-					fieldName = lowerCamelCase(structField.Name)
-				}
-
+				fieldName := naming.fieldName(structField, tag)
 				fieldList = append(fieldList, fieldName)
 				bBody = encString(bBody, fieldName)
 			}
@@ -222,8 +260,10 @@ func RegisterPOJOMapping(javaClassName string, o interface{}) int {
 	sttInfo.index = len(pojoRegistry.classInfoList)
 	pojoRegistry.classInfoList = append(pojoRegistry.classInfoList, &clsDef)
 	pojoRegistry.registry[sttInfo.goName] = &sttInfo
+	// the cached fields may be matched by another strategy
+	fieldIndexCache.Delete(sttInfo.typ)
 
-	return sttInfo.index
+	return sttInfo.index, nil
 }
 
 // UnRegisterPOJOs unregister POJO instances. It is easy for test.
@@ -62,6 +62,10 @@ func getArgType(v interface{}) string {
 		return "C"
 	case []uint16:
 		return "[C"
+	case JavaChar:
+		return "C"
+	case []JavaChar:
+		return "[C"
 	// case rune:
 	//	return "C"
 	case int:
@@ -94,9 +98,34 @@ func getArgType(v interface{}) string {
 		return "[Ljava.lang.String;"
 	case []Object:
 		return "[Ljava.lang.Object;"
-	case map[interface{}]interface{}:
+	// Serialized tags for wrapper types, nil pointer is encoded as null
+	case *bool:
+		return "java.lang.Boolean"
+	case *int8:
+		return "java.lang.Byte"
+	case *int16:
+		return "java.lang.Short"
+	case *uint16, *JavaChar:
+		return "java.lang.Character"
+	case *int32:
+		return "java.lang.Integer"
+	case *int64:
+		return "java.lang.Long"
+	case *float32:
+		return "java.lang.Float"
+	case *float64:
+		return "java.lang.Double"
+	case *string:
+		return "java.lang.String"
+	case map[interface{}]interface{}, *OrderedMap:
 		// return  "java.util.HashMap"
 		return "java.util.Map"
+	case map[string]interface{}:
+		// the map will be encoded as the class object, see Encoder.EncodeMapClass
+		if className, ok := v.(map[string]interface{})[ClassKey].(string); ok && className != "" {
+			return className
+		}
+		return "java.util.Map"
 	case POJOEnum:
 		return v.(POJOEnum).JavaClassName()
 	//  Serialized tags for complex types
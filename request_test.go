@@ -94,6 +94,30 @@ func TestGetArgsTypeList(t *testing.T) {
 	assert.Equal(t, "VJ[JZ[ZLjava/lang/String;[Ljava/lang/String;Ljava/lang/Object;Ljava/lang/Object;[Ljava/lang/Object;Ljava/util/Map;Lcom/ikurento/test/TestEnumGender;", str)
 }
 
+func TestGetArgsTypeListWrapper(t *testing.T) {
+	var (
+		i32    int32 = 1
+		i64    int64 = 2
+		nilInt *int32
+	)
+	str, err := getArgsTypeList([]interface{}{&i32, &i64, nilInt, new(bool), new(float64), new(string)})
+	assert.NoError(t, err)
+	assert.Equal(t, "Ljava/lang/Integer;Ljava/lang/Long;Ljava/lang/Integer;Ljava/lang/Boolean;Ljava/lang/Double;Ljava/lang/String;", str)
+
+	str, err = getArgsTypeList([]interface{}{
+		map[string]interface{}{ClassKey: "com.test.User", "name": "tom"},
+		map[string]interface{}{"name": "tom"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Lcom/test/User;Ljava/util/Map;", str)
+
+	// nil wrapper must be encoded as null
+	e := NewEncoder()
+	assert.Nil(t, e.Encode(nilInt))
+	assert.Nil(t, e.Encode((*int64)(nil)))
+	assert.Equal(t, []byte{BC_NULL, BC_NULL}, e.Buffer())
+}
+
 func TestDescRegex(t *testing.T) {
 	results := DescRegex.FindAllString("Ljava/lang/String;", -1)
 	assert.Equal(t, 1, len(results))
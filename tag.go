@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hessian
+
+import (
+	"reflect"
+	"strings"
+)
+
+// aliasTagOption is the tag option of the other accepted field names separated by '|', like:
+//
+//	AccountID string `hessian:"accountId,alias=acctId|accountID"`
+const aliasTagOption = "alias"
+
+// fieldTag is the parsed hessian tag of a struct field, the tag is the field name with options, like:
+//
+//	CreateTime time.Time `hessian:"createTime,time=Instant"`
+type fieldTag struct {
+	// name is the field name in the tag, it's empty if the tag has no name
+	name string
+	// ignored is true if the tag is "-"
+	ignored bool
+	// options are the key=value options after the name, the option without value has an empty value
+	options map[string]string
+}
+
+// parseFieldTag parses the tag of the struct field.
+func parseFieldTag(field reflect.StructField) fieldTag {
+	tag, ok := field.Tag.Lookup(tagIdentifier)
+	if !ok {
+		return fieldTag{}
+	}
+	if tag == "-" {
+		return fieldTag{ignored: true}
+	}
+
+	parts := strings.Split(tag, ",")
+	ft := fieldTag{name: strings.TrimSpace(parts[0])}
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if ft.options == nil {
+			ft.options = make(map[string]string)
+		}
+		if i := strings.IndexByte(part, '='); i >= 0 {
+			ft.options[strings.TrimSpace(part[:i])] = strings.TrimSpace(part[i+1:])
+		} else {
+			ft.options[part] = ""
+		}
+	}
+	return ft
+}
+
+// option returns the value of the tag option.
+func (t fieldTag) option(key string) (string, bool) {
+	v, ok := t.options[key]
+	return v, ok
+}
+
+// hasAlias returns whether @name is one of the aliases in the tag.
+func (t fieldTag) hasAlias(name string) bool {
+	aliases, ok := t.option(aliasTagOption)
+	if !ok {
+		return false
+	}
+	for _, alias := range strings.Split(aliases, "|") {
+		if strings.TrimSpace(alias) == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package org.apache.dubbo.common.serialize.hessian2.java8;
+
+import java.io.Serializable;
+import java.time.LocalDate;
+
+/**
+ * The same fields as the LocalDateHandle of apache dubbo 3, to write its layout without depending on dubbo 3.
+ */
+public class LocalDateHandle implements Serializable {
+    private static final long serialVersionUID = 1L;
+
+    private int year;
+    private int month;
+    private int day;
+
+    public LocalDateHandle(LocalDate date) {
+        this.year = date.getYear();
+        this.month = date.getMonthValue();
+        this.day = date.getDayOfMonth();
+    }
+}
@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test;
+
+import com.caucho.hessian.io.AbstractSerializerFactory;
+import com.caucho.hessian.io.Deserializer;
+import com.caucho.hessian.io.HessianProtocolException;
+import com.caucho.hessian.io.Serializer;
+import com.caucho.hessian.io.UnsafeSerializer;
+
+/**
+ * Writes the java.time objects with their own fields. Caucho hessian writes them through writeReplace
+ * as java.time.Ser by default, which keeps no value of them.
+ */
+public class CauchoJava8TimeSerializerFactory extends AbstractSerializerFactory {
+    @Override
+    public Serializer getSerializer(Class cl) throws HessianProtocolException {
+        if (cl.getName().startsWith("java.time.") && !cl.isEnum()) {
+            return new UnsafeSerializer(cl);
+        }
+        return null;
+    }
+
+    @Override
+    public Deserializer getDeserializer(Class cl) throws HessianProtocolException {
+        return null;
+    }
+}
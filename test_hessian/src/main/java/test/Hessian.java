@@ -71,6 +71,17 @@ public class Hessian {
             Hessian2Output output = new Hessian2Output(System.out);
             output.writeObject(object);
             output.flush();
+        } else if (args[0].startsWith("caucho_java8_")) {
+            // the java.time objects written by caucho hessian
+            Method method = TestJava8Time.class.getMethod(args[0].substring("caucho_".length()));
+            Object object = method.invoke(null);
+
+            com.caucho.hessian.io.SerializerFactory factory = new com.caucho.hessian.io.SerializerFactory();
+            factory.addFactory(new CauchoJava8TimeSerializerFactory());
+            com.caucho.hessian.io.Hessian2Output output = new com.caucho.hessian.io.Hessian2Output(System.out);
+            output.setSerializerFactory(factory);
+            output.writeObject(object);
+            output.flush();
         } else if (args[0].startsWith("javaSql_")) {
             if (args[0].startsWith("javaSql_encode")) {
 
@@ -29,11 +29,16 @@ import test.model.CustomMap;
 import test.model.DateDemo;
 import test.model.User;
 
+import java.io.File;
 import java.io.OutputStream;
 import java.io.Serializable;
 import java.math.BigDecimal;
 import java.math.BigInteger;
+import java.net.InetAddress;
+import java.net.URI;
+import java.net.URL;
 import java.util.ArrayList;
+import java.util.Currency;
 import java.util.Date;
 import java.util.EnumSet;
 import java.util.HashMap;
@@ -43,6 +48,7 @@ import java.util.Locale;
 import java.util.Map;
 import java.util.Set;
 import java.util.UUID;
+import java.util.regex.Pattern;
 
 
 public class TestCustomReply {
@@ -685,6 +691,18 @@ public class TestCustomReply {
         output.flush();
     }
 
+    public void customReplyJdkValues() throws Exception {
+        Map<String, Object> map = new HashMap<>();
+        map.put("url", new URL("https://dubbo.apache.org/docs?lang=en"));
+        map.put("uri", new URI("https://dubbo.apache.org/docs?lang=en"));
+        map.put("host", InetAddress.getByAddress("localhost", new byte[]{127, 0, 0, 1}));
+        map.put("file", new File("/home/dubbo"));
+        map.put("pattern", Pattern.compile("^dubbo.*", Pattern.CASE_INSENSITIVE));
+        map.put("currency", Currency.getInstance("EUR"));
+        output.writeObject(map);
+        output.flush();
+    }
+
     public void customReplyEnumSet() throws Exception {
         Map<String, Object> map = new HashMap<>();
         EnumSet<Locale.Category> enumSet = EnumSet.allOf(Locale.Category.class);
@@ -17,6 +17,9 @@
 
 package test;
 
+import org.apache.dubbo.common.serialize.hessian2.java8.InstantHandle;
+import org.apache.dubbo.common.serialize.hessian2.java8.LocalDateHandle;
+
 import java.time.*;
 
 public class TestJava8Time {
@@ -78,4 +81,11 @@ public class TestJava8Time {
         return ZoneOffset.ofHours(2);
     }
 
+    public static Object java8_Dubbo3Instant() {
+        return new InstantHandle(java8_Instant());
+    }
+
+    public static Object java8_Dubbo3LocalDate() {
+        return new LocalDateHandle(java8_LocalDate());
+    }
 }
@@ -0,0 +1,553 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	hessian "github.com/apache/dubbo-go-hessian2"
+)
+
+type Strings []string
+
+func (t *Strings) String() string {
+	return fmt.Sprint(*t)
+}
+
+func (t *Strings) Set(value string) error {
+	*t = append(*t, value)
+	return nil
+}
+
+const (
+	usage = `dubbo-invoke can invoke a dubbo service method like the telnet invoke command of java dubbo.
+
+Usage: dubbo-invoke [-v version] [-g group] [-t timeout] [-a key=value] [-generic] [-raw] host:port interface method [json_args]
+
+Options
+  -v	service version (eg: 1.0.0)
+  -g	service group
+  -t	request timeout, 3s when not specified (eg: 500ms)
+  -a	request attachment, can specify multiple (eg: -a traceId=123 -a tag=gray)
+  -generic	use generic invocation ($invoke), POJO arguments are sent as maps with a "class" member
+  -raw	hex dump the request and response frames
+
+Arguments
+  json_args is a json array of the method arguments. The java types are inferred from the json values:
+  string -> java.lang.String, integer -> int (long if out of range), decimal -> double,
+  boolean -> boolean, array -> java.util.List, object -> java.util.Map.
+  Use an object with a "_class" member to declare the java type:
+    {"_class": "long", "value": 1}
+    {"_class": "java.lang.Integer", "value": null}
+    {"_class": "java.util.Date", "value": "2020-06-16T06:05:04Z"}
+    {"_class": "int[]", "value": [1, 2, 3]}
+    {"_class": "com.test.User", "name": "tom", "age": {"_class": "int", "value": 18}}
+
+Example
+  dubbo-invoke 127.0.0.1:20880 com.test.UserService getUser '["u001"]'
+  dubbo-invoke -v 1.0.0 -generic 127.0.0.1:20880 com.test.UserService saveUser '[{"_class": "com.test.User", "name": "tom"}]'
+`
+
+	genericMethod = "$invoke"
+	genericKey    = "generic"
+	classKey      = "class"
+)
+
+var (
+	version     string
+	group       string
+	timeout     time.Duration
+	attachments Strings
+	generic     bool
+	raw         bool
+)
+
+func init() {
+	flag.StringVar(&version, "v", "", "")
+	flag.StringVar(&group, "g", "", "")
+	flag.DurationVar(&timeout, "t", 3*time.Second, "")
+	flag.Var(&attachments, "a", "")
+	flag.BoolVar(&generic, "generic", false, "")
+	flag.BoolVar(&raw, "raw", false, "")
+
+	flag.Usage = func() {
+		fmt.Print(usage)
+	}
+}
+
+func main() {
+	flag.Parse()
+
+	if flag.NArg() < 3 || flag.NArg() > 4 {
+		flag.Usage()
+		return
+	}
+
+	addr, iface, method := flag.Arg(0), flag.Arg(1), flag.Arg(2)
+	jsonArgs := "[]"
+	if flag.NArg() == 4 {
+		jsonArgs = flag.Arg(3)
+	}
+
+	args, types, err := parseArgs(jsonArgs)
+	if err != nil {
+		log.Fatalln("Error: can't parse arguments!!!", err)
+	}
+
+	atta := make(map[string]string, len(attachments))
+	for _, kv := range attachments {
+		i := strings.Index(kv, "=")
+		if i <= 0 {
+			log.Fatalln("Error: illegal attachment!!!", kv)
+		}
+		atta[kv[:i]] = kv[i+1:]
+	}
+
+	if generic {
+		for i := range args {
+			args[i] = toGenericValue(args[i])
+		}
+		args = []interface{}{method, types, toObjectSlice(args)}
+		method = genericMethod
+		atta[genericKey] = "true"
+	}
+
+	rsp, err := invoke(addr, hessian.Service{
+		Path:      iface,
+		Interface: iface,
+		Group:     group,
+		Version:   version,
+		Method:    method,
+		Timeout:   timeout,
+	}, hessian.NewRequest(args, atta))
+	if err != nil {
+		log.Fatalln("Error: invoke failed!!!", err)
+	}
+
+	out, err := json.MarshalIndent(rsp, "", "  ")
+	if err != nil {
+		log.Fatalln("Error: can't format response!!!", err)
+	}
+	fmt.Println(string(out))
+}
+
+// invoke sends the request and waits for the response with the same request id.
+func invoke(addr string, service hessian.Service, req *hessian.Request) (map[string]interface{}, error) {
+	header := hessian.DubboHeader{
+		SerialID: 2, // hessian2
+		Type:     hessian.PackageRequest_TwoWay,
+		ID:       time.Now().UnixNano() & math.MaxInt32,
+	}
+
+	frame, err := hessian.NewHessianCodec(nil).Write(service, header, req)
+	if err != nil {
+		return nil, perrors.Wrap(err, "encode request")
+	}
+	dump("request", frame)
+
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return nil, perrors.WithStack(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if err = conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, perrors.WithStack(err)
+	}
+	if _, err = conn.Write(frame); err != nil {
+		return nil, perrors.WithStack(err)
+	}
+
+	reader := bufio.NewReader(conn)
+	for {
+		frame, err = readFrame(reader)
+		if err != nil {
+			return nil, err
+		}
+		dump("response", frame)
+
+		codec := hessian.NewHessianCodec(bufio.NewReaderSize(bytes.NewReader(frame), len(frame)))
+		var rspHeader hessian.DubboHeader
+		if err = codec.ReadHeader(&rspHeader); err != nil {
+			return nil, err
+		}
+		// skip heartbeats and other requests from the server
+		if rspHeader.Type&hessian.PackageRequest != 0 || rspHeader.Type&hessian.PackageHeartbeat != 0 ||
+			rspHeader.ID != header.ID {
+			continue
+		}
+
+		rsp := &hessian.Response{}
+		if err = codec.ReadBody(rsp); err != nil {
+			return nil, err
+		}
+
+		result := map[string]interface{}{"status": rspHeader.ResponseStatus}
+		if rsp.Exception != nil {
+			result["exception"] = exceptionValue(rsp.Exception)
+		} else {
+			result["result"] = jsonValue(rsp.RspObj)
+		}
+		if len(rsp.Attachments) > 0 {
+			result["attachments"] = rsp.Attachments
+		}
+		return result, nil
+	}
+}
+
+// readFrame reads a whole dubbo frame including the header.
+func readFrame(r io.Reader) ([]byte, error) {
+	header := make([]byte, hessian.HEADER_LENGTH)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, perrors.WithStack(err)
+	}
+	if header[0] != hessian.MAGIC_HIGH || header[1] != hessian.MAGIC_LOW {
+		return nil, hessian.ErrIllegalPackage
+	}
+
+	bodyLen := binary.BigEndian.Uint32(header[12:16])
+	if bodyLen > hessian.DEFAULT_LEN {
+		return nil, perrors.Errorf("Data length %d too large, max payload %d", bodyLen, hessian.DEFAULT_LEN)
+	}
+
+	frame := make([]byte, hessian.HEADER_LENGTH+int(bodyLen))
+	copy(frame, header)
+	if _, err := io.ReadFull(r, frame[hessian.HEADER_LENGTH:]); err != nil {
+		return nil, perrors.WithStack(err)
+	}
+	return frame, nil
+}
+
+func dump(name string, frame []byte) {
+	if raw {
+		_, _ = fmt.Fprintf(os.Stderr, "%s frame (%d bytes):\n%s\n", name, len(frame), hex.Dump(frame))
+	}
+}
+
+/////////////////////////////////////////
+// arguments
+/////////////////////////////////////////
+
+// parseArgs converts the json arguments into go values and java type names.
+func parseArgs(s string) ([]interface{}, []string, error) {
+	d := json.NewDecoder(strings.NewReader(s))
+	d.UseNumber()
+
+	var values []interface{}
+	if err := d.Decode(&values); err != nil {
+		return nil, nil, perrors.WithStack(err)
+	}
+
+	args := make([]interface{}, len(values))
+	types := make([]string, len(values))
+	for i, v := range values {
+		if v == nil {
+			return nil, nil, perrors.Errorf("argument %d is null, use an object with _class member to declare its type", i)
+		}
+		arg, typ, err := convertArg(v)
+		if err != nil {
+			return nil, nil, perrors.Wrapf(err, "argument %d", i)
+		}
+		args[i], types[i] = arg, typ
+	}
+	return args, types, nil
+}
+
+// convertArg converts a json value into a go value, and returns the java type name of it.
+func convertArg(v interface{}) (interface{}, string, error) {
+	switch val := v.(type) {
+	case nil:
+		return nil, "java.lang.Object", nil
+	case bool:
+		return val, "boolean", nil
+	case string:
+		return val, "java.lang.String", nil
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			if i >= math.MinInt32 && i <= math.MaxInt32 {
+				return int32(i), "int", nil
+			}
+			return i, "long", nil
+		}
+		f, err := val.Float64()
+		return f, "double", perrors.WithStack(err)
+	case []interface{}:
+		list := make([]interface{}, len(val))
+		for i := range val {
+			item, _, err := convertArg(val[i])
+			if err != nil {
+				return nil, "", err
+			}
+			list[i] = item
+		}
+		return list, "java.util.List", nil
+	case map[string]interface{}:
+		className, ok := val[hessian.ClassKey].(string)
+		if !ok {
+			m := make(map[string]interface{}, len(val))
+			for k := range val {
+				item, _, err := convertArg(val[k])
+				if err != nil {
+					return nil, "", err
+				}
+				m[k] = item
+			}
+			return m, "java.util.Map", nil
+		}
+		if c, ok := typeConverters[className]; ok {
+			arg, err := c(val["value"])
+			return arg, className, perrors.Wrapf(err, "convert to %s", className)
+		}
+		// POJO, which is encoded as class object
+		m := make(map[string]interface{}, len(val))
+		for k := range val {
+			if k == hessian.ClassKey {
+				m[k] = className
+				continue
+			}
+			item, _, err := convertArg(val[k])
+			if err != nil {
+				return nil, "", err
+			}
+			m[k] = item
+		}
+		return m, className, nil
+	}
+	return nil, "", perrors.Errorf("unsupported json value %v", v)
+}
+
+type typeConverter func(interface{}) (interface{}, error)
+
+// typeConverters converts the "value" member of a typed json object into go value.
+var typeConverters map[string]typeConverter
+
+func init() {
+	typeConverters = map[string]typeConverter{
+		"boolean":            func(v interface{}) (interface{}, error) { b, ok := v.(bool); return b, checkType(ok, v) },
+		"byte":               func(v interface{}) (interface{}, error) { i, err := toInt(v, 8); return int8(i), err },
+		"short":              func(v interface{}) (interface{}, error) { i, err := toInt(v, 16); return int16(i), err },
+		"int":                func(v interface{}) (interface{}, error) { i, err := toInt(v, 32); return int32(i), err },
+		"long":               func(v interface{}) (interface{}, error) { return toInt(v, 64) },
+		"float":              func(v interface{}) (interface{}, error) { f, err := toFloat(v); return float32(f), err },
+		"double":             func(v interface{}) (interface{}, error) { return toFloat(v) },
+		"char":               func(v interface{}) (interface{}, error) { c, err := toChar(v); return hessian.JavaChar(c), err },
+		"java.lang.String":   func(v interface{}) (interface{}, error) { s, ok := v.(string); return s, checkType(ok, v) },
+		"java.lang.Boolean":  wrapper(func(v interface{}) (interface{}, error) { b, ok := v.(bool); return &b, checkType(ok, v) }, (*bool)(nil)),
+		"java.lang.Byte":     wrapper(func(v interface{}) (interface{}, error) { i, err := toInt(v, 8); b := int8(i); return &b, err }, (*int8)(nil)),
+		"java.lang.Short":    wrapper(func(v interface{}) (interface{}, error) { i, err := toInt(v, 16); s := int16(i); return &s, err }, (*int16)(nil)),
+		"java.lang.Integer":  wrapper(func(v interface{}) (interface{}, error) { i, err := toInt(v, 32); n := int32(i); return &n, err }, (*int32)(nil)),
+		"java.lang.Long":     wrapper(func(v interface{}) (interface{}, error) { i, err := toInt(v, 64); return &i, err }, (*int64)(nil)),
+		"java.lang.Float":    wrapper(func(v interface{}) (interface{}, error) { f, err := toFloat(v); f32 := float32(f); return &f32, err }, (*float32)(nil)),
+		"java.lang.Double":   wrapper(func(v interface{}) (interface{}, error) { f, err := toFloat(v); return &f, err }, (*float64)(nil)),
+		"java.util.Date":     toDate,
+		"int[]":              listOf(reflect.TypeOf([]int32{}), "int"),
+		"long[]":             listOf(reflect.TypeOf([]int64{}), "long"),
+		"short[]":            listOf(reflect.TypeOf([]int16{}), "short"),
+		"float[]":            listOf(reflect.TypeOf([]float32{}), "float"),
+		"double[]":           listOf(reflect.TypeOf([]float64{}), "double"),
+		"boolean[]":          listOf(reflect.TypeOf([]bool{}), "boolean"),
+		"java.lang.String[]": listOf(reflect.TypeOf([]string{}), "java.lang.String"),
+		"java.util.List":     func(v interface{}) (interface{}, error) { l, _, err := convertArg(v); return l, err },
+		"java.util.Map":      func(v interface{}) (interface{}, error) { m, _, err := convertArg(v); return m, err },
+	}
+}
+
+// wrapper returns nil pointer of the wrapper type for null value.
+func wrapper(c typeConverter, null interface{}) typeConverter {
+	return func(v interface{}) (interface{}, error) {
+		if v == nil {
+			return null, nil
+		}
+		return c(v)
+	}
+}
+
+// listOf converts a json array into a typed slice.
+func listOf(typ reflect.Type, elemType string) typeConverter {
+	return func(v interface{}) (interface{}, error) {
+		list, ok := v.([]interface{})
+		if !ok {
+			return nil, checkType(ok, v)
+		}
+		slice := reflect.MakeSlice(typ, len(list), len(list))
+		for i := range list {
+			item, err := typeConverters[elemType](list[i])
+			if err != nil {
+				return nil, err
+			}
+			slice.Index(i).Set(reflect.ValueOf(item))
+		}
+		return slice.Interface(), nil
+	}
+}
+
+func checkType(ok bool, v interface{}) error {
+	if !ok {
+		return perrors.Errorf("unexpected value %v", v)
+	}
+	return nil
+}
+
+func toInt(v interface{}, bits int) (int64, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, checkType(ok, v)
+	}
+	i, err := n.Int64()
+	if err != nil {
+		return 0, perrors.WithStack(err)
+	}
+	if bits < 64 && (i < -(1<<uint(bits-1)) || i >= 1<<uint(bits-1)) {
+		return 0, perrors.Errorf("%d overflows %d bits integer", i, bits)
+	}
+	return i, nil
+}
+
+func toFloat(v interface{}) (float64, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, checkType(ok, v)
+	}
+	f, err := n.Float64()
+	return f, perrors.WithStack(err)
+}
+
+// toChar converts a one character string or a code point into a java char.
+func toChar(v interface{}) (uint16, error) {
+	if s, ok := v.(string); ok {
+		r := []rune(s)
+		if len(r) != 1 || r[0] > 0xffff {
+			return 0, perrors.Errorf("illegal char %q", s)
+		}
+		return uint16(r[0]), nil
+	}
+	i, err := toInt(v, 17)
+	if err != nil || i < 0 {
+		return 0, perrors.Errorf("illegal char %v", v)
+	}
+	return uint16(i), nil
+}
+
+// toDate converts milliseconds since epoch or a RFC3339 string into time.
+func toDate(v interface{}) (interface{}, error) {
+	if s, ok := v.(string); ok {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		return t, perrors.WithStack(err)
+	}
+	ms, err := toInt(v, 64)
+	if err != nil {
+		return nil, err
+	}
+	return time.Unix(ms/1000, ms%1000*int64(time.Millisecond)), nil
+}
+
+func toObjectSlice(args []interface{}) []hessian.Object {
+	objects := make([]hessian.Object, len(args))
+	for i := range args {
+		objects[i] = args[i]
+	}
+	return objects
+}
+
+// toGenericValue converts POJO maps into the maps which can be realized by the generic filter of java dubbo.
+func toGenericValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case []interface{}:
+		list := make([]interface{}, len(val))
+		for i := range val {
+			list[i] = toGenericValue(val[i])
+		}
+		return list
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			if k == hessian.ClassKey {
+				m[classKey] = item
+				continue
+			}
+			m[k] = toGenericValue(item)
+		}
+		return m
+	}
+	return v
+}
+
+/////////////////////////////////////////
+// result
+/////////////////////////////////////////
+
+// jsonValue converts the decoded value into the value which can be marshaled into json.
+func jsonValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[fmt.Sprint(jsonValue(k))] = jsonValue(item)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[k] = jsonValue(item)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, len(val))
+		for i := range val {
+			list[i] = jsonValue(val[i])
+		}
+		return list
+	case float64:
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			return fmt.Sprint(val)
+		}
+	}
+	return v
+}
+
+// exceptionValue formats the java exception with its class name.
+func exceptionValue(err error) interface{} {
+	e := perrors.Cause(err)
+	result := map[string]interface{}{"message": e.Error()}
+	if p, ok := e.(hessian.POJO); ok {
+		result[hessian.ClassKey] = p.JavaClassName()
+	}
+	return result
+}
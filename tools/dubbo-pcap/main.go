@@ -0,0 +1,219 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"github.com/apache/dubbo-go-hessian2/pcap"
+)
+
+const (
+	usage = `dubbo-pcap can decode dubbo requests and responses from tcpdump captures.
+
+Usage: dubbo-pcap [-p port] [-s service] [-m method] [-b] capture_file
+
+Options
+  -p	only show messages sent to or from the port (eg: 20880)
+  -s	only show messages of the service, responses are matched by request id
+  -m	only show messages of the method, responses are matched by request id
+  -b	show heartbeat events
+
+Example
+  tcpdump -i any -w dubbo.pcap port 20880
+  dubbo-pcap -p 20880 dubbo.pcap
+  dubbo-pcap -s com.test.UserService -m getUser dubbo.pcapng
+`
+
+	timeLayout = "2006-01-02 15:04:05.000000"
+)
+
+var (
+	port          int
+	service       string
+	method        string
+	showHeartbeat bool
+)
+
+func init() {
+	flag.IntVar(&port, "p", 0, "")
+	flag.StringVar(&service, "s", "", "")
+	flag.StringVar(&method, "m", "", "")
+	flag.BoolVar(&showHeartbeat, "b", false, "")
+
+	flag.Usage = func() {
+		fmt.Print(usage)
+	}
+}
+
+func main() {
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		return
+	}
+
+	f, err := os.Open(flag.Arg(0))
+	if err != nil {
+		log.Fatalln("Error: can't open capture file!!!", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	reader, err := pcap.NewReader(f)
+	if err != nil {
+		log.Fatalln("Error: can't read capture file!!!", err)
+	}
+
+	// the requests which are shown, responses are matched by connection and request id
+	shown := make(map[string]bool)
+	for {
+		m, err := reader.Next()
+		if err != nil {
+			if !perrors.Is(err, io.EOF) {
+				log.Fatalln("Error: can't read capture file!!!", err)
+			}
+			return
+		}
+
+		if !match(m, shown) {
+			continue
+		}
+		printMessage(os.Stdout, m)
+	}
+}
+
+func match(m *pcap.Message, shown map[string]bool) bool {
+	if m.IsHeartbeat() && !showHeartbeat {
+		return false
+	}
+	if port != 0 && !hasPort(m.Src, port) && !hasPort(m.Dst, port) {
+		return false
+	}
+	if service == "" && method == "" {
+		return true
+	}
+
+	if m.IsRequest() {
+		if (service != "" && m.Service != service && m.Attachments["interface"] != service) ||
+			(method != "" && m.Method != method) {
+			return false
+		}
+		shown[requestKey(m.Src, m.Dst, m.Header.ID)] = true
+		return true
+	}
+
+	key := requestKey(m.Dst, m.Src, m.Header.ID)
+	if shown[key] {
+		delete(shown, key)
+		return true
+	}
+	return false
+}
+
+func requestKey(client, server string, id int64) string {
+	return client + ">" + server + "#" + strconv.FormatInt(id, 10)
+}
+
+func hasPort(addr string, port int) bool {
+	_, p, err := net.SplitHostPort(addr)
+	return err == nil && p == strconv.Itoa(port)
+}
+
+func printMessage(w io.Writer, m *pcap.Message) {
+	kind := "response"
+	if m.IsRequest() {
+		kind = "request"
+	}
+	if m.IsHeartbeat() {
+		kind = "heartbeat " + kind
+	}
+	_, _ = fmt.Fprintf(w, "%s %s -> %s %s id=%d", m.Time.Format(timeLayout), m.Src, m.Dst, kind, m.Header.ID)
+	if m.IsRequest() {
+		if m.IsTwoWay() {
+			_, _ = fmt.Fprint(w, " two-way")
+		}
+	} else {
+		_, _ = fmt.Fprintf(w, " status=%d", m.Header.ResponseStatus)
+	}
+	_, _ = fmt.Fprintln(w)
+
+	if m.Err != nil {
+		_, _ = fmt.Fprintf(w, "  error:       %v\n", m.Err)
+	}
+
+	if m.IsRequest() && !m.IsHeartbeat() {
+		_, _ = fmt.Fprintf(w, "  service:     %s\n", m.Service)
+		if m.Version != "" {
+			_, _ = fmt.Fprintf(w, "  version:     %s\n", m.Version)
+		}
+		if group := m.Attachments["group"]; group != "" {
+			_, _ = fmt.Fprintf(w, "  group:       %s\n", group)
+		}
+		_, _ = fmt.Fprintf(w, "  method:      %s(%s)\n", m.Method, m.ArgTypes)
+		for i, arg := range m.Args {
+			_, _ = fmt.Fprintf(w, "  args[%d]:     %s\n", i, format(arg))
+		}
+	} else if !m.IsRequest() {
+		if m.Exception != nil {
+			_, _ = fmt.Fprintf(w, "  exception:   %s\n", format(m.Exception))
+		} else {
+			_, _ = fmt.Fprintf(w, "  result:      %s\n", format(m.Result))
+		}
+	}
+
+	if len(m.Attachments) > 0 {
+		keys := make([]string, 0, len(m.Attachments))
+		for k := range m.Attachments {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		pairs := make([]string, 0, len(keys))
+		for _, k := range keys {
+			pairs = append(pairs, k+"="+m.Attachments[k])
+		}
+		_, _ = fmt.Fprintf(w, "  attachments: %s\n", strings.Join(pairs, ", "))
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+func format(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(val)
+	case error:
+		return fmt.Sprintf("%T: %s", val, val.Error())
+	}
+	return fmt.Sprintf("%+v", v)
+}
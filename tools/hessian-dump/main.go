@@ -0,0 +1,241 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+import (
+	hessian "github.com/apache/dubbo-go-hessian2"
+)
+
+const (
+	usage = `hessian-dump can disassemble a hessian2 payload without any POJO registration.
+
+Usage: hessian-dump [-f format] [-l] [-t] [file]
+
+Options
+  -f	input format: auto, hex, base64 or raw, default is auto
+  -l	only show the annotated listing
+  -t	only show the value tree
+
+The payload is read from stdin if no file is given. The 16 bytes header is
+skipped if the payload is a dubbo frame.
+
+Example
+  echo '48 01 6b e1 5a' | hessian-dump
+  hessian-dump -f raw body.bin
+`
+)
+
+var (
+	format      string
+	listingOnly bool
+	treeOnly    bool
+)
+
+func init() {
+	flag.StringVar(&format, "f", "auto", "")
+	flag.BoolVar(&listingOnly, "l", false, "")
+	flag.BoolVar(&treeOnly, "t", false, "")
+
+	flag.Usage = func() {
+		fmt.Print(usage)
+	}
+}
+
+func main() {
+	flag.Parse()
+
+	if flag.NArg() > 1 {
+		flag.Usage()
+		return
+	}
+
+	var (
+		input []byte
+		err   error
+	)
+	if flag.NArg() == 1 {
+		input, err = ioutil.ReadFile(flag.Arg(0))
+	} else {
+		input, err = ioutil.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		log.Fatalln("Error: can't read input!!!", err)
+	}
+
+	data, err := decodeInput(input, format)
+	if err != nil {
+		log.Fatalln("Error: can't decode input!!!", err)
+	}
+
+	if len(data) >= hessian.HEADER_LENGTH && data[0] == hessian.MAGIC_HIGH && data[1] == hessian.MAGIC_LOW {
+		fmt.Printf("dubbo header: % x\n\n", data[:hessian.HEADER_LENGTH])
+		data = data[hessian.HEADER_LENGTH:]
+	}
+
+	if !treeOnly {
+		insts, err := hessian.Disassemble(data)
+		for _, inst := range insts {
+			fmt.Println(inst.String())
+		}
+		if err != nil {
+			log.Fatalln("Error: can't disassemble payload!!!", err)
+		}
+	}
+
+	if !listingOnly {
+		nodes, err := hessian.ParseValueTree(data)
+		if !treeOnly {
+			fmt.Println()
+		}
+		for _, node := range nodes {
+			printNode(os.Stdout, node, 0, "")
+		}
+		if err != nil {
+			log.Fatalln("Error: can't parse payload!!!", err)
+		}
+	}
+}
+
+// decodeInput decodes the input by the format, the auto format tries hex, base64 and raw in turn.
+func decodeInput(input []byte, format string) ([]byte, error) {
+	switch format {
+	case "raw":
+		return input, nil
+	case "hex":
+		return decodeHex(input)
+	case "base64":
+		return decodeBase64(input)
+	case "auto":
+		if data, err := decodeHex(input); err == nil {
+			return data, nil
+		}
+		if data, err := decodeBase64(input); err == nil {
+			return data, nil
+		}
+		return input, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// decodeHex decodes hex string, the spaces, colons and 0x prefixes are ignored.
+func decodeHex(input []byte) ([]byte, error) {
+	s := strings.Join(strings.Fields(string(input)), "")
+	s = strings.ReplaceAll(s, ":", "")
+	s = strings.ReplaceAll(s, "0x", "")
+	if s == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+	return hex.DecodeString(s)
+}
+
+func decodeBase64(input []byte) ([]byte, error) {
+	s := strings.Join(strings.Fields(string(input)), "")
+	if s == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+	if data, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return data, nil
+	}
+	return base64.URLEncoding.DecodeString(s)
+}
+
+// printNode prints the value tree, the name is the list index, map key or field name.
+func printNode(w io.Writer, node *hessian.ValueNode, depth int, name string) {
+	indent := strings.Repeat("  ", depth)
+	if name != "" {
+		name += ": "
+	}
+
+	switch node.Kind {
+	case hessian.KindList:
+		fmt.Fprintf(w, "%s%slist%s #%d (%d items)\n", indent, name, typeText(node.Type), node.Ref, len(node.Children))
+		for i, child := range node.Children {
+			printNode(w, child, depth+1, "["+strconv.Itoa(i)+"]")
+		}
+
+	case hessian.KindMap:
+		fmt.Fprintf(w, "%s%smap%s #%d (%d entries)\n", indent, name, typeText(node.Type), node.Ref, len(node.Children))
+		for i, child := range node.Children {
+			key := node.Keys[i]
+			if key.Kind == hessian.KindList || key.Kind == hessian.KindMap || key.Kind == hessian.KindObject {
+				printNode(w, key, depth+1, "key")
+				printNode(w, child, depth+1, "value")
+				continue
+			}
+			printNode(w, child, depth+1, scalarText(key))
+		}
+
+	case hessian.KindObject:
+		fmt.Fprintf(w, "%s%sobject %s #%d\n", indent, name, node.Type, node.Ref)
+		for i, child := range node.Children {
+			printNode(w, child, depth+1, node.Fields[i])
+		}
+
+	default:
+		fmt.Fprintf(w, "%s%s%s\n", indent, name, scalarText(node))
+	}
+}
+
+func typeText(typ string) string {
+	if typ == "" {
+		return ""
+	}
+	return " " + typ
+}
+
+func scalarText(node *hessian.ValueNode) string {
+	switch node.Kind {
+	case hessian.KindNull:
+		return "null"
+	case hessian.KindRef:
+		return "ref #" + strconv.Itoa(node.Ref)
+	case hessian.KindString:
+		return strconv.Quote(node.Value.(string))
+	case hessian.KindLong:
+		return strconv.FormatInt(node.Value.(int64), 10) + "L"
+	case hessian.KindDouble:
+		return strconv.FormatFloat(node.Value.(float64), 'g', -1, 64) + "D"
+	case hessian.KindDate:
+		return node.Value.(time.Time).Format(time.RFC3339Nano)
+	case hessian.KindBinary:
+		b := node.Value.([]byte)
+		if len(b) > 32 {
+			return fmt.Sprintf("binary(%d) %x...", len(b), b[:32])
+		}
+		return fmt.Sprintf("binary(%d) %x", len(b), b)
+	case hessian.KindList, hessian.KindMap, hessian.KindObject:
+		return fmt.Sprintf("%s #%d", node.Kind, node.Ref)
+	default:
+		return fmt.Sprint(node.Value)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+)
+
+import (
+	hessian "github.com/apache/dubbo-go-hessian2"
+)
+
+const (
+	usage = `hessian-json can convert a hessian2 value stream to json, and convert the json back.
+
+Usage: hessian-json [-d] [-f format] [-r] [-c] [file]
+
+Options
+  -d	convert json to hessian2
+  -f	hessian2 format: auto, hex, base64 or raw, default is auto for input and hex for output
+  -r	resolve refs to the referred values, the refs to ancestors are kept
+  -c	output compact json
+
+The input is read from stdin if no file is given. The 16 bytes header is
+skipped if the hessian2 payload is a dubbo frame.
+
+Example
+  echo '48 01 6b e1 5a' | hessian-json
+  echo '[{"k": {"_long": 1}}]' | hessian-json -d
+`
+)
+
+var (
+	toHessian bool
+	format    string
+	resolve   bool
+	compact   bool
+)
+
+func init() {
+	flag.BoolVar(&toHessian, "d", false, "")
+	flag.StringVar(&format, "f", "", "")
+	flag.BoolVar(&resolve, "r", false, "")
+	flag.BoolVar(&compact, "c", false, "")
+
+	flag.Usage = func() {
+		fmt.Print(usage)
+	}
+}
+
+func main() {
+	flag.Parse()
+
+	if flag.NArg() > 1 {
+		flag.Usage()
+		return
+	}
+
+	var (
+		input []byte
+		err   error
+	)
+	if flag.NArg() == 1 {
+		input, err = ioutil.ReadFile(flag.Arg(0))
+	} else {
+		input, err = ioutil.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		log.Fatalln("Error: can't read input!!!", err)
+	}
+
+	if toHessian {
+		data, err := hessian.JSONToHessian(input)
+		if err != nil {
+			log.Fatalln("Error: can't convert json!!!", err)
+		}
+		if err = writeHessian(data); err != nil {
+			log.Fatalln("Error: can't write output!!!", err)
+		}
+		return
+	}
+
+	if format == "" {
+		format = "auto"
+	}
+	data, err := decodeInput(input, format)
+	if err != nil {
+		log.Fatalln("Error: can't decode input!!!", err)
+	}
+	if len(data) >= hessian.HEADER_LENGTH && data[0] == hessian.MAGIC_HIGH && data[1] == hessian.MAGIC_LOW {
+		data = data[hessian.HEADER_LENGTH:]
+	}
+
+	out, err := hessian.HessianToJSON(data, resolve)
+	if err != nil {
+		log.Fatalln("Error: can't convert hessian2!!!", err)
+	}
+	if !compact {
+		var buf bytes.Buffer
+		if err = json.Indent(&buf, out, "", "  "); err != nil {
+			log.Fatalln("Error: can't format json!!!", err)
+		}
+		out = buf.Bytes()
+	}
+	fmt.Println(string(out))
+}
+
+func writeHessian(data []byte) error {
+	switch format {
+	case "", "auto", "hex":
+		_, err := fmt.Println(hex.EncodeToString(data))
+		return err
+	case "base64":
+		_, err := fmt.Println(base64.StdEncoding.EncodeToString(data))
+		return err
+	case "raw":
+		_, err := os.Stdout.Write(data)
+		return err
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// decodeInput decodes the input by the format, the auto format tries hex, base64 and raw in turn.
+func decodeInput(input []byte, format string) ([]byte, error) {
+	switch format {
+	case "raw":
+		return input, nil
+	case "hex":
+		return decodeHex(input)
+	case "base64":
+		return decodeBase64(input)
+	case "auto":
+		if data, err := decodeHex(input); err == nil {
+			return data, nil
+		}
+		if data, err := decodeBase64(input); err == nil {
+			return data, nil
+		}
+		return input, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// decodeHex decodes hex string, the spaces, colons and 0x prefixes are ignored.
+func decodeHex(input []byte) ([]byte, error) {
+	s := strings.Join(strings.Fields(string(input)), "")
+	s = strings.ReplaceAll(s, ":", "")
+	s = strings.ReplaceAll(s, "0x", "")
+	if s == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+	return hex.DecodeString(s)
+}
+
+func decodeBase64(input []byte) ([]byte, error) {
+	s := strings.Join(strings.Fields(string(input)), "")
+	if s == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+	if data, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return data, nil
+	}
+	return base64.URLEncoding.DecodeString(s)
+}